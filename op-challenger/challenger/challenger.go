@@ -11,16 +11,33 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	ethclient "github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/log"
+	"golang.org/x/time/rate"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/config"
+	"github.com/ethereum-optimism/optimism/op-challenger/fault"
 	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
 
 	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-node/client"
 	"github.com/ethereum-optimism/optimism/op-node/eth"
 	opclient "github.com/ethereum-optimism/optimism/op-service/client"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 )
 
+const (
+	// l1RateLimitBurst is the burst of requests each method of the L1 providers may exceed its rate limit by.
+	l1RateLimitBurst = 10
+
+	// contractsBudget is the rate budget of the contract reads, which are never limited beyond
+	// the rate limit of the L1 providers.
+	contractsBudget = "contracts"
+	// healthBudget is the rate budget of the health checks. They are served on demand,
+	// so they are limited to keep an aggressive readiness probe from exhausting the providers.
+	healthBudget      = "health"
+	healthRateLimit   = rate.Limit(2)
+	healthBudgetBurst = 4
+)
+
 type OutputAPI interface {
 	OutputAtBlock(ctx context.Context, blockNum uint64) (*eth.OutputResponse, error)
 }
@@ -38,8 +55,13 @@ type Challenger struct {
 	cancel context.CancelFunc
 
 	l1Client *ethclient.Client
+	// l1RPC serves the contract reads and the health checks, see [dialL1RPC].
+	l1RPC client.RPC
+	// l1Health is the L1 client of the health checks, limited to the health budget of l1RPC.
+	l1Health *ethclient.Client
 
 	rollupClient OutputAPI
+	rollupStatus SyncStatusProvider
 
 	// l2 Output Oracle contract
 	l2ooContract     *bindings.L2OutputOracleCaller
@@ -52,6 +74,10 @@ type Challenger struct {
 	dgfABI          *abi.ABI
 
 	networkTimeout time.Duration
+
+	// controls lets operators intervene at runtime through the admin API.
+	// They only apply to responders created with [fault.Controls.Responder].
+	controls *fault.Controls
 }
 
 // From returns the address of the account used to send transactions.
@@ -59,6 +85,13 @@ func (c *Challenger) From() common.Address {
 	return c.txMgr.From()
 }
 
+// Controls returns the runtime controls of the challenger.
+// The challenger does not respond in any game yet, so pausing or ignoring games through the
+// controls has no effect until responders are created from them, as the [fault.Orchestrator] does.
+func (c *Challenger) Controls() *fault.Controls {
+	return c.controls
+}
+
 // Client returns the client for the settlement layer.
 func (c *Challenger) Client() *ethclient.Client {
 	return c.l1Client
@@ -82,7 +115,7 @@ func (c *Challenger) NewFactorySubscription() (*Subscription, error) {
 }
 
 // NewChallenger creates a new Challenger
-func NewChallenger(cfg config.Config, l log.Logger, m metrics.Metricer) (*Challenger, error) {
+func NewChallenger(cfg config.Config, l log.Logger, m metrics.Metricer) (_ *Challenger, err error) {
 	ctx, cancel := context.WithCancel(context.Background())
 
 	txManager, err := txmgr.NewSimpleTxManager("challenger", l, m, *cfg.TxMgrConfig)
@@ -104,13 +137,39 @@ func NewChallenger(cfg config.Config, l log.Logger, m metrics.Metricer) (*Challe
 		return nil, err
 	}
 
-	l2ooContract, err := bindings.NewL2OutputOracleCaller(cfg.L2OOAddress, l1Client)
+	// Contract reads and health checks are served by a separate client, which fails over between
+	// the L1 endpoints if fallbacks are configured. Subscriptions always use the primary endpoint,
+	// since the client is shared over HTTP and cannot subscribe.
+	l1RPC, err := dialL1RPC(ctx, l.New("client", "l1"), cfg, m)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	// A fallback client checks the health of the endpoints in the background until it is closed.
+	defer func() {
+		if err != nil {
+			l1RPC.Close()
+		}
+	}()
+	budgets := client.NewRateBudgets(l1RPC)
+	l1Reads, err := opclient.NewEthClient(ctx, budgets.Client(contractsBudget, rate.Inf, 0))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	l1Health, err := opclient.NewEthClient(ctx, budgets.Client(healthBudget, healthRateLimit, healthBudgetBurst))
 	if err != nil {
 		cancel()
 		return nil, err
 	}
 
-	dgfContract, err := bindings.NewDisputeGameFactoryCaller(cfg.DGFAddress, l1Client)
+	l2ooContract, err := bindings.NewL2OutputOracleCaller(cfg.L2OOAddress, l1Reads)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	dgfContract, err := bindings.NewDisputeGameFactoryCaller(cfg.DGFAddress, l1Reads)
 	if err != nil {
 		cancel()
 		return nil, err
@@ -148,8 +207,11 @@ func NewChallenger(cfg config.Config, l log.Logger, m metrics.Metricer) (*Challe
 		cancel: cancel,
 
 		rollupClient: rollupClient,
+		rollupStatus: rollupClient,
 
 		l1Client: l1Client,
+		l1RPC:    l1RPC,
+		l1Health: l1Health,
 
 		l2ooContract:     l2ooContract,
 		l2ooContractAddr: cfg.L2OOAddress,
@@ -160,9 +222,41 @@ func NewChallenger(cfg config.Config, l log.Logger, m metrics.Metricer) (*Challe
 		dgfABI:          parsedDgf,
 
 		networkTimeout: cfg.NetworkTimeout,
+
+		controls: fault.NewControls(),
 	}, nil
 }
 
+// dialL1RPC dials the L1 endpoints used for contract reads. If fallbacks are configured, the
+// returned client is a [opclient.FallbackClient] over all endpoints, created with the given options
+// in addition to the [fallbackOptions]. If a rate limit is configured, each endpoint limits every
+// method to it, and lowers the limit while the endpoint reports rate limiting.
+func dialL1RPC(ctx context.Context, lgr log.Logger, cfg config.Config, m metrics.Metricer, opts ...opclient.FallbackClientOption) (client.RPC, error) {
+	var rpcOpts []client.RPCOption
+	if cfg.L1EthRpcRateLimit != 0 {
+		rpcOpts = append(rpcOpts, client.WithAdaptiveRateLimit(cfg.L1EthRpcRateLimit, l1RateLimitBurst))
+	}
+	if len(cfg.L1EthRpcFallbacks) == 0 {
+		return client.NewRPC(ctx, lgr, cfg.L1EthRpc, rpcOpts...)
+	}
+	urls := append([]string{cfg.L1EthRpc}, cfg.L1EthRpcFallbacks...)
+	fallback, err := opclient.NewFallbackRPC(ctx, lgr, urls, rpcOpts, append(fallbackOptions(cfg, m), opts...)...)
+	if err != nil {
+		return nil, err
+	}
+	return fallback, nil
+}
+
+// fallbackOptions returns the options of the client that fails over between the L1 endpoints.
+// Endpoints that degrade beyond the configured thresholds are demoted, and the health of
+// every endpoint is recorded in the challenger metrics.
+func fallbackOptions(cfg config.Config, m metrics.Metricer) []opclient.FallbackClientOption {
+	return []opclient.FallbackClientOption{
+		opclient.WithDemotion(cfg.L1EthRpcMaxLatency, cfg.L1EthRpcMaxErrorRate),
+		opclient.WithFallbackMetrics(m),
+	}
+}
+
 // Start runs the challenger in a goroutine.
 func (c *Challenger) Start() error {
 	c.log.Error("challenger not implemented.")
@@ -174,4 +268,5 @@ func (c *Challenger) Stop() {
 	c.cancel()
 	close(c.done)
 	c.wg.Wait()
+	c.l1RPC.Close()
 }
@@ -0,0 +1,113 @@
+package challenger
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/config"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	opclient "github.com/ethereum-optimism/optimism/op-service/client"
+)
+
+type demotionMetrics struct {
+	metrics.Metricer
+	mu      sync.Mutex
+	demoted map[int]bool
+}
+
+func (m *demotionMetrics) RecordRPCEndpointDemoted(endpoint int, demoted bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.demoted[endpoint] = demoted
+}
+
+func (m *demotionMetrics) isDemoted(endpoint int) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.demoted[endpoint]
+}
+
+// serveRPC serves every request with the same result, unless fail returns true for its method,
+// in which case the request fails with an HTTP error.
+func serveRPC(t *testing.T, fail func(method string) bool) *httptest.Server {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if fail(req.Method) {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": req.ID, "result": "0x1"})
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// TestDialL1RPC_DemotesFailingEndpoint tests that the L1 endpoints of the challenger are
+// demoted once their error rate exceeds the configured threshold, and that it is recorded in the metrics.
+func TestDialL1RPC_DemotesFailingEndpoint(t *testing.T) {
+	m := &demotionMetrics{Metricer: metrics.NoopMetrics, demoted: make(map[int]bool)}
+	// The failing endpoint answers health check probes until it is demoted, so that requests
+	// keep switching back to it. Afterwards it fails everything, so that it stays demoted.
+	failing := serveRPC(t, func(method string) bool { return method != "eth_chainId" || m.isDemoted(0) })
+	healthy := serveRPC(t, func(string) bool { return false })
+	cfg := config.Config{L1EthRpc: failing.URL, L1EthRpcFallbacks: []string{healthy.URL}, L1EthRpcMaxErrorRate: 0.5}
+
+	ctx := context.Background()
+	l1RPC, err := dialL1RPC(ctx, log.New(), cfg, m, opclient.WithHealthCheckInterval(5*time.Millisecond))
+	require.NoError(t, err)
+	defer l1RPC.Close()
+	fallback, ok := l1RPC.(*opclient.FallbackClient)
+	require.True(t, ok)
+	l1, err := opclient.NewEthClient(ctx, l1RPC)
+	require.NoError(t, err)
+
+	// Every read fails over to the healthy endpoint, and the health check switches back to the
+	// failing endpoint as long as it answers the probe, until it is demoted.
+	require.Eventually(t, func() bool {
+		_, err := l1.BlockNumber(ctx)
+		require.NoError(t, err)
+		return m.isDemoted(0)
+	}, 10*time.Second, time.Millisecond)
+	require.True(t, fallback.Demoted(0))
+	require.False(t, m.isDemoted(1))
+	require.Eventually(t, func() bool { return fallback.Active() == 1 }, time.Second, time.Millisecond)
+}
+
+// TestDialL1RPC_RateLimit tests that the L1 endpoint is rate-limited if a rate limit is configured.
+func TestDialL1RPC_RateLimit(t *testing.T) {
+	srv := serveRPC(t, func(string) bool { return false })
+	cfg := config.Config{L1EthRpc: srv.URL, L1EthRpcRateLimit: 1}
+
+	ctx := context.Background()
+	l1RPC, err := dialL1RPC(ctx, log.New(), cfg, metrics.NoopMetrics)
+	require.NoError(t, err)
+	defer l1RPC.Close()
+	l1, err := opclient.NewEthClient(ctx, l1RPC)
+	require.NoError(t, err)
+
+	// The burst is served right away, after which requests wait for the limit.
+	for i := 0; i < l1RateLimitBurst; i++ {
+		_, err := l1.BlockNumber(ctx)
+		require.NoError(t, err)
+	}
+	cCtx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancel()
+	_, err = l1.BlockNumber(cCtx)
+	require.Error(t, err)
+}
@@ -0,0 +1,127 @@
+package challenger
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-node/eth"
+)
+
+var ErrNoBalance = errors.New("account has no balance to send transactions")
+
+// HealthCheck checks a subsystem. It returns a short description of the subsystem's state,
+// and an error if the subsystem is unhealthy.
+type HealthCheck func(ctx context.Context) (string, error)
+
+// SyncStatusProvider is a minimal interface around [sources.RollupClient].
+type SyncStatusProvider interface {
+	SyncStatus(ctx context.Context) (*eth.SyncStatus, error)
+}
+
+// SubsystemHealth is the health of a single subsystem.
+type SubsystemHealth struct {
+	Healthy bool   `json:"healthy"`
+	Status  string `json:"status,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// HealthResponse is the response of the [HealthHandler].
+type HealthResponse struct {
+	Version    string                     `json:"version"`
+	Healthy    bool                       `json:"healthy"`
+	Subsystems map[string]SubsystemHealth `json:"subsystems"`
+}
+
+// HealthHandler serves the health of the challenger's subsystems. It responds with
+// 200 OK if all subsystems are healthy and 503 Service Unavailable otherwise,
+// so it can be used directly as a readiness probe.
+type HealthHandler struct {
+	version string
+	timeout time.Duration
+	checks  map[string]HealthCheck
+}
+
+// NewHealthHandler creates a new [HealthHandler]. Each check runs with the given timeout.
+func NewHealthHandler(version string, timeout time.Duration, checks map[string]HealthCheck) *HealthHandler {
+	return &HealthHandler{
+		version: version,
+		timeout: timeout,
+		checks:  checks,
+	}
+}
+
+// Check runs all health checks.
+func (h *HealthHandler) Check(ctx context.Context) HealthResponse {
+	names := make([]string, 0, len(h.checks))
+	for name := range h.checks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	resp := HealthResponse{
+		Version:    h.version,
+		Healthy:    true,
+		Subsystems: make(map[string]SubsystemHealth, len(h.checks)),
+	}
+	for _, name := range names {
+		cCtx, cancel := context.WithTimeout(ctx, h.timeout)
+		status, err := h.checks[name](cCtx)
+		cancel()
+		health := SubsystemHealth{Healthy: err == nil, Status: status}
+		if err != nil {
+			health.Error = err.Error()
+			resp.Healthy = false
+		}
+		resp.Subsystems[name] = health
+	}
+	return resp
+}
+
+func (h *HealthHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	resp := h.Check(r.Context())
+	w.Header().Set("Content-Type", "application/json")
+	if !resp.Healthy {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	_ = json.NewEncoder(w).Encode(&resp)
+}
+
+// HealthChecks returns the health checks of the challenger's subsystems.
+func (c *Challenger) HealthChecks() map[string]HealthCheck {
+	return map[string]HealthCheck{
+		"l1": func(ctx context.Context) (string, error) {
+			head, err := c.l1Health.BlockNumber(ctx)
+			if err != nil {
+				return "", fmt.Errorf("failed to fetch L1 head: %w", err)
+			}
+			return fmt.Sprintf("head %d", head), nil
+		},
+		"rollup": func(ctx context.Context) (string, error) {
+			status, err := c.rollupStatus.SyncStatus(ctx)
+			if err != nil {
+				return "", fmt.Errorf("failed to fetch sync status: %w", err)
+			}
+			return fmt.Sprintf("safe L2 %d, finalized L2 %d", status.SafeL2.Number, status.FinalizedL2.Number), nil
+		},
+		"wallet": func(ctx context.Context) (string, error) {
+			balance, err := c.l1Health.BalanceAt(ctx, c.From(), nil)
+			if err != nil {
+				return "", fmt.Errorf("failed to fetch balance: %w", err)
+			}
+			status := fmt.Sprintf("%v balance %v wei", c.From(), balance)
+			if balance.Sign() == 0 {
+				return status, ErrNoBalance
+			}
+			return status, nil
+		},
+		"sending": func(ctx context.Context) (string, error) {
+			// Pausing is an operator decision, so a paused challenger is still healthy.
+			return fmt.Sprintf("paused %v, %d moves in flight", c.controls.Paused(), len(c.controls.InFlight())), nil
+		},
+	}
+}
@@ -0,0 +1,61 @@
+package challenger
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func serveHealth(t *testing.T, handler *HealthHandler) (int, HealthResponse) {
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	var resp HealthResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	return rec.Code, resp
+}
+
+// TestHealthHandler_Healthy tests that a challenger with healthy subsystems reports 200 OK.
+func TestHealthHandler_Healthy(t *testing.T) {
+	handler := NewHealthHandler("v1.2.3", time.Second, map[string]HealthCheck{
+		"l1": func(ctx context.Context) (string, error) { return "head 5", nil },
+	})
+	code, resp := serveHealth(t, handler)
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, HealthResponse{
+		Version:    "v1.2.3",
+		Healthy:    true,
+		Subsystems: map[string]SubsystemHealth{"l1": {Healthy: true, Status: "head 5"}},
+	}, resp)
+}
+
+// TestHealthHandler_Unhealthy tests that a single unhealthy subsystem fails the whole check.
+func TestHealthHandler_Unhealthy(t *testing.T) {
+	handler := NewHealthHandler("v1.2.3", time.Second, map[string]HealthCheck{
+		"l1":     func(ctx context.Context) (string, error) { return "head 5", nil },
+		"wallet": func(ctx context.Context) (string, error) { return "balance 0 wei", ErrNoBalance },
+	})
+	code, resp := serveHealth(t, handler)
+	require.Equal(t, http.StatusServiceUnavailable, code)
+	require.False(t, resp.Healthy)
+	require.True(t, resp.Subsystems["l1"].Healthy)
+	require.Equal(t, SubsystemHealth{Healthy: false, Status: "balance 0 wei", Error: ErrNoBalance.Error()}, resp.Subsystems["wallet"])
+}
+
+// TestHealthHandler_Timeout tests that checks are bounded by the timeout.
+func TestHealthHandler_Timeout(t *testing.T) {
+	handler := NewHealthHandler("v1.2.3", 10*time.Millisecond, map[string]HealthCheck{
+		"rollup": func(ctx context.Context) (string, error) {
+			<-ctx.Done()
+			return "", errors.New("timed out")
+		},
+	})
+	code, resp := serveHealth(t, handler)
+	require.Equal(t, http.StatusServiceUnavailable, code)
+	require.Equal(t, "timed out", resp.Subsystems["rollup"].Error)
+}
@@ -8,8 +8,6 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
-
-	"github.com/ethereum-optimism/optimism/op-service/backoff"
 )
 
 // logStore manages log subscriptions.
@@ -96,33 +94,6 @@ func (l *logStore) Quit() {
 	l.subscription.Quit()
 }
 
-// buildBackoffStrategy builds a [backoff.Strategy].
-func (l *logStore) buildBackoffStrategy() backoff.Strategy {
-	return &backoff.ExponentialStrategy{
-		Min:       1000,
-		Max:       20_000,
-		MaxJitter: 250,
-	}
-}
-
-// resubscribe attempts to re-establish the log store internal
-// subscription with a backoff strategy.
-func (l *logStore) resubscribe(ctx context.Context) error {
-	l.log.Info("log store resubscribing with backoff")
-	backoffStrategy := l.buildBackoffStrategy()
-	return backoff.DoCtx(ctx, 10, backoffStrategy, func() error {
-		if l.subscription == nil {
-			l.log.Error("subscription zeroed out")
-			return nil
-		}
-		err := l.subscription.Subscribe()
-		if err == nil {
-			l.log.Info("subscription reconnected", "id", l.subscription.ID())
-		}
-		return err
-	})
-}
-
 // insertLog inserts a log into the log store.
 func (l *logStore) insertLog(log types.Log) {
 	l.mu.Lock()
@@ -135,15 +106,8 @@ func (l *logStore) insertLog(log types.Log) {
 // This function is intended to be run as a goroutine.
 func (l *logStore) dispatchLogs(ctx context.Context) {
 	for {
+		// The subscription resubscribes and backfills missed logs by itself when dropped.
 		select {
-		case err := <-l.subscription.sub.Err():
-			l.log.Error("log subscription error", "err", err)
-			for {
-				err = l.resubscribe(ctx)
-				if err == nil {
-					break
-				}
-			}
 		case log := <-l.subscription.logs:
 			l.insertLog(log)
 		case <-l.subscription.quit:
@@ -3,6 +3,7 @@ package challenger
 import (
 	"context"
 	"errors"
+	"sync"
 	"testing"
 	"time"
 
@@ -17,8 +18,11 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+// mockLogStoreClient records the subscriptions made by the log store.
+// The log store subscribes from its own goroutine, so the fields are guarded by mu.
 type mockLogStoreClient struct {
 	sub      mockSubscription
+	mu       sync.Mutex
 	logs     chan<- types.Log
 	subcount int
 }
@@ -36,11 +40,27 @@ func (m *mockLogStoreClient) FilterLogs(context.Context, ethereum.FilterQuery) (
 }
 
 func (m *mockLogStoreClient) SubscribeFilterLogs(ctx context.Context, query ethereum.FilterQuery, logs chan<- types.Log) (ethereum.Subscription, error) {
-	m.subcount = m.subcount + 1
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	m.logs = logs
+	m.subcount = m.subcount + 1
 	return m.sub, nil
 }
 
+// subCount returns the number of subscriptions made.
+func (m *mockLogStoreClient) subCount() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.subcount
+}
+
+// logsChan returns the channel of the latest subscription.
+func (m *mockLogStoreClient) logsChan() chan<- types.Log {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.logs
+}
+
 var (
 	ErrTestError = errors.New("test error")
 )
@@ -93,11 +113,11 @@ func TestLogStore_NewLogStore_EmptyLogs(t *testing.T) {
 func TestLogStore_Subscribe_EstablishesSubscription(t *testing.T) {
 	logStore, client := newLogStore(t)
 	defer logStore.Quit()
-	require.Equal(t, 0, client.subcount)
+	require.Equal(t, 0, client.subCount())
 	require.False(t, logStore.Subscribed())
 	require.NoError(t, logStore.Subscribe(context.Background()))
 	require.True(t, logStore.Subscribed())
-	require.Equal(t, 1, client.subcount)
+	require.Equal(t, 1, client.subCount())
 }
 
 func TestLogStore_Subscribe_ReceivesLogs(t *testing.T) {
@@ -108,7 +128,7 @@ func TestLogStore_Subscribe_ReceivesLogs(t *testing.T) {
 	mockLog := types.Log{
 		BlockHash: common.HexToHash("0x1"),
 	}
-	client.logs <- mockLog
+	client.logsChan() <- mockLog
 
 	timeout, tCancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer tCancel()
@@ -129,13 +149,38 @@ func TestLogStore_Subscribe_SubscriptionErrors(t *testing.T) {
 	timeout, tCancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer tCancel()
 	err := e2eutils.WaitFor(timeout, 500*time.Millisecond, func() (bool, error) {
-		subcount := client.subcount == 2
+		subcount := client.subCount() == 2
 		started := logStore.subscription.Started()
 		return subcount && started, nil
 	})
 	require.NoError(t, err)
 }
 
+func TestLogStore_Subscribe_ReceivesLogsAfterResubscribing(t *testing.T) {
+	logStore, client := newLogStore(t)
+	defer logStore.Quit()
+	require.NoError(t, logStore.Subscribe(context.Background()))
+
+	client.sub.errorChan <- ErrTestError
+
+	timeout, tCancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer tCancel()
+	err := e2eutils.WaitFor(timeout, 500*time.Millisecond, func() (bool, error) {
+		return client.subCount() == 2, nil
+	})
+	require.NoError(t, err)
+
+	// Logs of the new subscription reach the store.
+	mockLog := types.Log{
+		BlockHash: common.HexToHash("0x2"),
+	}
+	client.logsChan() <- mockLog
+	err = e2eutils.WaitFor(timeout, 500*time.Millisecond, func() (bool, error) {
+		return len(logStore.GetLogByBlockHash(mockLog.BlockHash)) == 1, nil
+	})
+	require.NoError(t, err)
+}
+
 func TestLogStore_Subscribe_NoClient_Panics(t *testing.T) {
 	require.Panics(t, func() {
 		logStore, _ := newErrorLogStore(t, nil)
@@ -0,0 +1,72 @@
+package challenger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// ErrPinnedBlockReorged is returned when the pinned block kept being reorged out
+// for every attempt to read a consistent view.
+var ErrPinnedBlockReorged = errors.New("pinned block was reorged out")
+
+// DefaultPinnedReadAttempts is the number of attempts to read a consistent view that commands make.
+const DefaultPinnedReadAttempts = 3
+
+// HeaderSource provides the block headers needed to pin reads to a block.
+type HeaderSource interface {
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+}
+
+// PinnedReader pins all contract reads of a unit of work, e.g. a single solver tick,
+// to one block so that everything observed in that unit of work is mutually consistent.
+// After the reads, the pinned block is checked to still be canonical. If it was reorged out,
+// the reader re-pins to the new head and performs the reads again.
+type PinnedReader struct {
+	client   HeaderSource
+	log      log.Logger
+	attempts int
+}
+
+// NewPinnedReader creates a new [PinnedReader] that retries the reads at most attempts times on reorgs.
+func NewPinnedReader(client HeaderSource, log log.Logger, attempts int) *PinnedReader {
+	if attempts < 1 {
+		attempts = 1
+	}
+	return &PinnedReader{
+		client:   client,
+		log:      log,
+		attempts: attempts,
+	}
+}
+
+// Read pins the latest block and calls fn with call options reading at that block.
+// fn may be called several times if the pinned block is reorged out while reading,
+// so it must not retain any results of a previous call.
+// The hash of the block the successful reads were pinned to is returned.
+func (r *PinnedReader) Read(ctx context.Context, fn func(opts *bind.CallOpts) error) (common.Hash, error) {
+	for attempt := 0; attempt < r.attempts; attempt++ {
+		pinned, err := r.client.HeaderByNumber(ctx, nil)
+		if err != nil {
+			return common.Hash{}, fmt.Errorf("failed to fetch head to pin: %w", err)
+		}
+		if err := fn(&bind.CallOpts{Context: ctx, BlockNumber: pinned.Number}); err != nil {
+			return common.Hash{}, err
+		}
+		canonical, err := r.client.HeaderByNumber(ctx, pinned.Number)
+		if err != nil {
+			return common.Hash{}, fmt.Errorf("failed to verify pinned block %v: %w", pinned.Number, err)
+		}
+		if canonical.Hash() == pinned.Hash() {
+			return pinned.Hash(), nil
+		}
+		r.log.Warn("Pinned block reorged out, re-pinning", "number", pinned.Number, "pinned", pinned.Hash(), "canonical", canonical.Hash())
+	}
+	return common.Hash{}, fmt.Errorf("%w after %d attempts", ErrPinnedBlockReorged, r.attempts)
+}
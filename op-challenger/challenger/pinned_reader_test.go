@@ -0,0 +1,89 @@
+package challenger
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+)
+
+// mockHeaderSource returns the queued headers in order.
+type mockHeaderSource struct {
+	headers []*types.Header
+	numbers []*big.Int
+}
+
+func (m *mockHeaderSource) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	m.numbers = append(m.numbers, number)
+	if len(m.headers) == 0 {
+		return nil, errors.New("no headers left")
+	}
+	h := m.headers[0]
+	m.headers = m.headers[1:]
+	return h, nil
+}
+
+func header(number int64, extra byte) *types.Header {
+	return &types.Header{Number: big.NewInt(number), Extra: []byte{extra}}
+}
+
+// TestPinnedReader_ReadsAtPinnedBlock tests that reads are pinned to the head block.
+func TestPinnedReader_ReadsAtPinnedBlock(t *testing.T) {
+	head := header(10, 0)
+	client := &mockHeaderSource{headers: []*types.Header{head, head}}
+	reader := NewPinnedReader(client, testlog.Logger(t, log.LvlError), 3)
+
+	var reads []*big.Int
+	hash, err := reader.Read(context.Background(), func(opts *bind.CallOpts) error {
+		reads = append(reads, opts.BlockNumber)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, head.Hash(), hash)
+	require.Equal(t, []*big.Int{big.NewInt(10)}, reads)
+	require.Equal(t, []*big.Int{nil, big.NewInt(10)}, client.numbers)
+}
+
+// TestPinnedReader_RepinsOnReorg tests that reads are repeated at the new head
+// when the pinned block is reorged out.
+func TestPinnedReader_RepinsOnReorg(t *testing.T) {
+	newHead := header(11, 1)
+	client := &mockHeaderSource{headers: []*types.Header{header(10, 0), header(10, 1), newHead, newHead}}
+	reader := NewPinnedReader(client, testlog.Logger(t, log.LvlError), 3)
+
+	var reads []*big.Int
+	hash, err := reader.Read(context.Background(), func(opts *bind.CallOpts) error {
+		reads = append(reads, opts.BlockNumber)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, newHead.Hash(), hash)
+	require.Equal(t, []*big.Int{big.NewInt(10), big.NewInt(11)}, reads)
+}
+
+// TestPinnedReader_GivesUpAfterAttempts tests that the reader fails
+// if the pinned block is reorged out on every attempt.
+func TestPinnedReader_GivesUpAfterAttempts(t *testing.T) {
+	client := &mockHeaderSource{headers: []*types.Header{header(10, 0), header(10, 1)}}
+	reader := NewPinnedReader(client, testlog.Logger(t, log.LvlError), 1)
+
+	_, err := reader.Read(context.Background(), func(opts *bind.CallOpts) error { return nil })
+	require.ErrorIs(t, err, ErrPinnedBlockReorged)
+}
+
+// TestPinnedReader_ReturnsReadErrors tests that errors from the reads are returned as is.
+func TestPinnedReader_ReturnsReadErrors(t *testing.T) {
+	client := &mockHeaderSource{headers: []*types.Header{header(10, 0)}}
+	reader := NewPinnedReader(client, testlog.Logger(t, log.LvlError), 3)
+
+	readErr := errors.New("boom")
+	_, err := reader.Read(context.Background(), func(opts *bind.CallOpts) error { return readErr })
+	require.ErrorIs(t, err, readErr)
+}
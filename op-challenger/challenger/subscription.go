@@ -6,6 +6,8 @@ import (
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
+
+	opclient "github.com/ethereum-optimism/optimism/op-service/client"
 )
 
 // SubscriptionId is a unique subscription ID.
@@ -18,6 +20,8 @@ func (s *SubscriptionId) Increment() SubscriptionId {
 }
 
 // Subscription wraps an [ethereum.Subscription] to provide a restart.
+// The underlying log subscription resubscribes automatically when dropped,
+// backfilling any logs missed in the meantime.
 type Subscription struct {
 	// The subscription ID
 	id SubscriptionId
@@ -69,7 +73,7 @@ func (s *Subscription) Logs() <-chan types.Log {
 // Subscribe constructs the subscription.
 func (s *Subscription) Subscribe() error {
 	s.log.Info("Subscribing to", "query", s.query.Topics, "id", s.id)
-	sub, err := s.client.SubscribeFilterLogs(context.Background(), s.query, s.logs)
+	sub, err := opclient.SubscribeLogs(context.Background(), s.log, s.client, s.query, s.logs)
 	if err != nil {
 		s.log.Error("failed to subscribe to logs", "err", err)
 		return err
@@ -0,0 +1,157 @@
+package diff
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-challenger/challenger"
+	"github.com/ethereum-optimism/optimism/op-challenger/config"
+	"github.com/ethereum-optimism/optimism/op-challenger/fault"
+	"github.com/ethereum-optimism/optimism/op-challenger/flags"
+	opclient "github.com/ethereum-optimism/optimism/op-service/client"
+)
+
+var (
+	GameAddressFlag = &cli.StringFlag{
+		Name:     "game-address",
+		Usage:    "Address of the fault dispute game to diff.",
+		Required: true,
+	}
+	FromBlockFlag = &cli.Uint64Flag{
+		Name:  "from-block",
+		Usage: "L1 block of the first observation. Defaults to the latest block. Required with --to-block.",
+	}
+	ToBlockFlag = &cli.Uint64Flag{
+		Name:  "to-block",
+		Usage: "L1 block of the second observation. Must not be before --from-block. Defaults to the latest block.",
+	}
+	CompareRpcFlag = &cli.StringFlag{
+		Name:  "compare-rpc",
+		Usage: "L1 RPC to load the second observation from. Defaults to --l1-eth-rpc.",
+	}
+)
+
+var (
+	ErrNothingToCompare = errors.New("either --from-block, --to-block or --compare-rpc must be set")
+	// ErrBlocksReversed is returned when the first observation would be read after the second.
+	ErrBlocksReversed = errors.New("--from-block must be set and not after --to-block")
+)
+
+// Command diffs the claims of a game between two blocks or two RPC providers.
+var Command = &cli.Command{
+	Name:  "diff-game",
+	Usage: "Diffs the claims of a fault dispute game between two blocks or two RPC providers",
+	Flags: []cli.Flag{GameAddressFlag, FromBlockFlag, ToBlockFlag, CompareRpcFlag},
+	Action: func(ctx *cli.Context) error {
+		if !ctx.IsSet(FromBlockFlag.Name) && !ctx.IsSet(ToBlockFlag.Name) && !ctx.IsSet(CompareRpcFlag.Name) {
+			return ErrNothingToCompare
+		}
+		fromBlock, toBlock := blockFlag(ctx, FromBlockFlag), blockFlag(ctx, ToBlockFlag)
+		if err := checkBlockOrder(fromBlock, toBlock); err != nil {
+			return err
+		}
+		logger, err := config.LoggerFromCLI(ctx)
+		if err != nil {
+			return err
+		}
+		game := common.HexToAddress(ctx.String(GameAddressFlag.Name))
+		rpc := ctx.String(flags.L1EthRpcFlag.Name)
+		compareRpc := rpc
+		if ctx.IsSet(CompareRpcFlag.Name) {
+			compareRpc = ctx.String(CompareRpcFlag.Name)
+		}
+		before, err := loadClaims(ctx.Context, logger, rpc, game, fromBlock)
+		if err != nil {
+			return err
+		}
+		after, err := loadClaims(ctx.Context, logger, compareRpc, game, toBlock)
+		if err != nil {
+			return err
+		}
+		writeDiff(os.Stdout, fault.DiffClaims(before, after))
+		return nil
+	},
+}
+
+func blockFlag(ctx *cli.Context, flag *cli.Uint64Flag) *big.Int {
+	if !ctx.IsSet(flag.Name) {
+		return nil
+	}
+	return new(big.Int).SetUint64(ctx.Uint64(flag.Name))
+}
+
+// checkBlockOrder rejects observations that would be diffed backwards.
+// An unset block is the latest block, so --to-block needs an earlier --from-block.
+func checkBlockOrder(from *big.Int, to *big.Int) error {
+	if to == nil {
+		return nil
+	}
+	if from == nil || from.Cmp(to) > 0 {
+		return ErrBlocksReversed
+	}
+	return nil
+}
+
+// loadClaims loads the claims of the game at the given block.
+// A nil block reads the latest block through a [challenger.PinnedReader], so that all claims come from the same block.
+func loadClaims(ctx context.Context, logger log.Logger, rpc string, gameAddr common.Address, block *big.Int) ([]fault.Claim, error) {
+	client, err := opclient.DialEthClientWithTimeout(ctx, rpc, opclient.DefaultDialTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial %v: %w", rpc, err)
+	}
+	defer client.Close()
+	game, err := bindings.NewFaultDisputeGameCaller(gameAddr, client)
+	if err != nil {
+		return nil, err
+	}
+	var maxDepth *big.Int
+	var claims []fault.Claim
+	read := func(opts *bind.CallOpts) error {
+		maxDepth, err = game.MAXGAMEDEPTH(opts)
+		if err != nil {
+			return fmt.Errorf("failed to fetch max game depth: %w", err)
+		}
+		claims, err = fault.NewLoader(game).FetchClaimsWithOpts(opts)
+		return err
+	}
+	if block != nil {
+		err = read(&bind.CallOpts{Context: ctx, BlockNumber: block})
+	} else {
+		_, err = challenger.NewPinnedReader(client, logger, challenger.DefaultPinnedReadAttempts).Read(ctx, read)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := fault.ValidateClaims(claims, int(maxDepth.Uint64())); err != nil {
+		return nil, fmt.Errorf("invalid claims from %v: %w", rpc, err)
+	}
+	return claims, nil
+}
+
+func writeDiff(w io.Writer, diff fault.GameDiff) {
+	if diff.Empty() {
+		fmt.Fprintln(w, "No differences")
+		return
+	}
+	writeClaims(w, "+", "added", diff.Added)
+	writeClaims(w, "-", "removed", diff.Removed)
+	writeClaims(w, "~", "countered", diff.Countered)
+	writeClaims(w, "~", "uncountered", diff.Uncountered)
+}
+
+func writeClaims(w io.Writer, prefix string, change string, claims []fault.Claim) {
+	for _, claim := range claims {
+		fmt.Fprintf(w, "%s %s: index %d depth %d index at depth %d value %v\n",
+			prefix, change, claim.ContractIndex, claim.Depth(), claim.IndexAtDepth(), claim.Value)
+	}
+}
@@ -0,0 +1,18 @@
+package diff
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestCheckBlockOrder tests that the first observation must not be read after the second.
+func TestCheckBlockOrder(t *testing.T) {
+	require.NoError(t, checkBlockOrder(nil, nil))
+	require.NoError(t, checkBlockOrder(big.NewInt(5), nil))
+	require.NoError(t, checkBlockOrder(big.NewInt(5), big.NewInt(5)))
+	require.NoError(t, checkBlockOrder(big.NewInt(5), big.NewInt(6)))
+	require.ErrorIs(t, checkBlockOrder(nil, big.NewInt(5)), ErrBlocksReversed)
+	require.ErrorIs(t, checkBlockOrder(big.NewInt(6), big.NewInt(5)), ErrBlocksReversed)
+}
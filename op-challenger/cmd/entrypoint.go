@@ -6,9 +6,11 @@ import (
 	_ "net/http/pprof"
 
 	"github.com/ethereum/go-ethereum/log"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/config"
 	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	challengerrpc "github.com/ethereum-optimism/optimism/op-challenger/rpc"
 	"github.com/ethereum-optimism/optimism/op-service/opio"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/challenger"
@@ -65,7 +67,25 @@ func Main(logger log.Logger, version string, cfg *config.Config) error {
 	}
 
 	rpcCfg := cfg.RPCConfig
-	server := rpc.NewServer(rpcCfg.ListenAddr, rpcCfg.ListenPort, version, rpc.WithLogger(logger))
+	healthHandler := challenger.NewHealthHandler(version, cfg.NetworkTimeout, service.HealthChecks())
+	serverOpts := []rpc.ServerOption{rpc.WithLogger(logger), rpc.WithHealthzHandler(healthHandler)}
+	adminCfg := cfg.AdminRPCConfig
+	if adminCfg.EnableAdmin {
+		secret, err := adminCfg.JWTSecret()
+		if err != nil {
+			cancel()
+			return err
+		}
+		serverOpts = append(serverOpts, rpc.WithJWTSecret(secret))
+	}
+	server := rpc.NewServer(rpcCfg.ListenAddr, rpcCfg.ListenPort, version, serverOpts...)
+	if adminCfg.EnableAdmin {
+		server.AddAPI(gethrpc.API{
+			Namespace: "admin",
+			Service:   challengerrpc.NewAdminAPI(service.Controls()),
+		})
+		logger.Info("Admin RPC enabled")
+	}
 	if err := server.Start(); err != nil {
 		cancel()
 		return fmt.Errorf("error starting RPC server: %w", err)
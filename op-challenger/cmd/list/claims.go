@@ -0,0 +1,173 @@
+package list
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-challenger/challenger"
+	"github.com/ethereum-optimism/optimism/op-challenger/fault"
+)
+
+const (
+	agree    = "agree"
+	disagree = "disagree"
+)
+
+// claimInfo describes a claim in a fault dispute game.
+type claimInfo struct {
+	Index        int             `json:"index"`
+	ParentIndex  int             `json:"parentIndex"`
+	Depth        int             `json:"depth"`
+	IndexAtDepth int             `json:"indexAtDepth"`
+	TraceIndex   uint64          `json:"traceIndex"`
+	Value        common.Hash     `json:"value"`
+	Countered    bool            `json:"countered"`
+	Claimant     *common.Address `json:"claimant,omitempty"`
+	Agrees       *bool           `json:"agrees,omitempty"`
+}
+
+// claimFilter selects the claims to list. Nil fields match any claim.
+type claimFilter struct {
+	depth     *int
+	countered *bool
+	claimant  *common.Address
+	agrees    *bool
+}
+
+func (f claimFilter) matches(claim claimInfo) bool {
+	if f.depth != nil && *f.depth != claim.Depth {
+		return false
+	}
+	if f.countered != nil && *f.countered != claim.Countered {
+		return false
+	}
+	if f.claimant != nil && (claim.Claimant == nil || *f.claimant != *claim.Claimant) {
+		return false
+	}
+	if f.agrees != nil && (claim.Agrees == nil || *f.agrees != *claim.Agrees) {
+		return false
+	}
+	return true
+}
+
+func claimFilterFromCLI(ctx *cli.Context) (claimFilter, error) {
+	var filter claimFilter
+	if ctx.IsSet(DepthFlag.Name) {
+		depth := ctx.Int(DepthFlag.Name)
+		filter.depth = &depth
+	}
+	if ctx.IsSet(CounteredFlag.Name) {
+		countered := ctx.Bool(CounteredFlag.Name)
+		filter.countered = &countered
+	}
+	if ctx.IsSet(ClaimantFlag.Name) {
+		value := ctx.String(ClaimantFlag.Name)
+		if !common.IsHexAddress(value) {
+			return claimFilter{}, fmt.Errorf("invalid claimant address: %v", value)
+		}
+		claimant := common.HexToAddress(value)
+		filter.claimant = &claimant
+	}
+	if ctx.IsSet(AgreementFlag.Name) {
+		if !ctx.IsSet(TraceAlphabetFlag.Name) {
+			return claimFilter{}, fmt.Errorf("flag %s requires %s", AgreementFlag.Name, TraceAlphabetFlag.Name)
+		}
+		var agrees bool
+		switch ctx.String(AgreementFlag.Name) {
+		case agree:
+			agrees = true
+		case disagree:
+			agrees = false
+		default:
+			return claimFilter{}, fmt.Errorf("invalid agreement %q, allowed values are %s and %s", ctx.String(AgreementFlag.Name), agree, disagree)
+		}
+		filter.agrees = &agrees
+	}
+	return filter, nil
+}
+
+// l1Client is the subset of [ethclient.Client] used to list claims.
+type l1Client interface {
+	bind.ContractBackend
+	challenger.HeaderSource
+}
+
+// fetchClaims fetches all claims of the game that match the filter.
+// The claims are read at a single L1 block, so that they are consistent with each other,
+// and their claimants are taken from the Move events up to that block.
+// If alphabet is not empty, each claim is compared against an [fault.AlphabetProvider] trace.
+func fetchClaims(ctx context.Context, logger log.Logger, client l1Client, gameAddr common.Address, alphabet string, filter claimFilter) ([]claimInfo, error) {
+	game, err := bindings.NewFaultDisputeGame(gameAddr, client)
+	if err != nil {
+		return nil, err
+	}
+	var maxDepth *big.Int
+	var claims []fault.Claim
+	reader := challenger.NewPinnedReader(client, logger, challenger.DefaultPinnedReadAttempts)
+	_, err = reader.Read(ctx, func(opts *bind.CallOpts) error {
+		maxDepth, err = game.MAXGAMEDEPTH(opts)
+		if err != nil {
+			return fmt.Errorf("failed to fetch max game depth: %w", err)
+		}
+		claims, err = fault.NewLoader(&game.FaultDisputeGameCaller).FetchAnnotatedClaims(opts, &game.FaultDisputeGameFilterer)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := fault.ValidateClaims(claims, int(maxDepth.Uint64())); err != nil {
+		return nil, err
+	}
+	var trace fault.TraceProvider
+	if alphabet != "" {
+		trace = fault.NewAlphabetProvider(alphabet, maxDepth.Uint64())
+	}
+
+	var infos []claimInfo
+	for _, claim := range claims {
+		info, err := newClaimInfo(claim, int(maxDepth.Uint64()), trace)
+		if err != nil {
+			return nil, err
+		}
+		if filter.matches(info) {
+			infos = append(infos, info)
+		}
+	}
+	return infos, nil
+}
+
+func newClaimInfo(claim fault.Claim, maxDepth int, trace fault.TraceProvider) (claimInfo, error) {
+	info := claimInfo{
+		Index:        claim.ContractIndex,
+		ParentIndex:  claim.ParentContractIndex,
+		Depth:        claim.Depth(),
+		IndexAtDepth: claim.IndexAtDepth(),
+		TraceIndex:   claim.TraceIndex(maxDepth),
+		Value:        claim.Value,
+		Countered:    claim.Countered,
+	}
+	// The root claim is created with the game and does not have a Move event.
+	if claim.Provenance != (fault.Provenance{}) {
+		claimant := claim.Provenance.Claimant
+		info.Claimant = &claimant
+	}
+	if claim.IsRoot() {
+		info.ParentIndex = -1
+	}
+	if trace != nil {
+		expected, err := trace.Get(info.TraceIndex)
+		if err != nil {
+			return claimInfo{}, fmt.Errorf("failed to get trace at index %d: %w", info.TraceIndex, err)
+		}
+		agrees := expected == claim.Value
+		info.Agrees = &agrees
+	}
+	return info, nil
+}
@@ -0,0 +1,80 @@
+package list
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/fault"
+)
+
+// TestClaimFilter_Matches tests that every filter field restricts the listed claims.
+func TestClaimFilter_Matches(t *testing.T) {
+	claimant := common.Address{0xaa}
+	other := common.Address{0xbb}
+	yes, no := true, false
+	depth := 2
+	claim := claimInfo{Depth: 2, Countered: true, Claimant: &claimant, Agrees: &no}
+
+	require.True(t, claimFilter{}.matches(claim))
+	require.True(t, claimFilter{depth: &depth, countered: &yes, claimant: &claimant, agrees: &no}.matches(claim))
+
+	otherDepth := 3
+	require.False(t, claimFilter{depth: &otherDepth}.matches(claim))
+	require.False(t, claimFilter{countered: &no}.matches(claim))
+	require.False(t, claimFilter{claimant: &other}.matches(claim))
+	require.False(t, claimFilter{agrees: &yes}.matches(claim))
+	require.False(t, claimFilter{claimant: &claimant}.matches(claimInfo{}), "claims with unknown claimant should not match")
+}
+
+// TestNewClaimInfo_Agreement tests that claims are compared against the local trace.
+func TestNewClaimInfo_Agreement(t *testing.T) {
+	trace := fault.NewAlphabetProvider("abcdefgh", 3)
+	pos := fault.NewPosition(3, 2)
+	expected, err := trace.Get(pos.TraceIndex(3))
+	require.NoError(t, err)
+
+	claimant := common.Address{0xaa}
+	info, err := newClaimInfo(fault.Claim{
+		ClaimData:           fault.ClaimData{Value: expected, Position: pos},
+		ContractIndex:       4,
+		ParentContractIndex: 3,
+		Provenance:          fault.Provenance{L1Block: 7, Claimant: claimant},
+	}, 3, trace)
+	require.NoError(t, err)
+	require.True(t, *info.Agrees)
+	require.Equal(t, &claimant, info.Claimant)
+	require.Equal(t, 3, info.ParentIndex)
+	require.Equal(t, uint64(2), info.TraceIndex)
+
+	info, err = newClaimInfo(fault.Claim{ClaimData: fault.ClaimData{Value: common.Hash{0x01}, Position: pos}}, 3, trace)
+	require.NoError(t, err)
+	require.False(t, *info.Agrees)
+
+	root, err := newClaimInfo(fault.Claim{ClaimData: fault.ClaimData{Position: fault.NewPosition(0, 0)}}, 3, nil)
+	require.NoError(t, err)
+	require.Equal(t, -1, root.ParentIndex)
+	require.Nil(t, root.Agrees)
+	require.Nil(t, root.Claimant)
+}
+
+// TestWriteClaims tests both output formats.
+func TestWriteClaims(t *testing.T) {
+	claims := []claimInfo{{Index: 1, Depth: 1, Value: common.Hash{0x01}}}
+
+	var out bytes.Buffer
+	require.NoError(t, writeClaims(&out, outputJSON, claims))
+	var decoded []claimInfo
+	require.NoError(t, json.Unmarshal(out.Bytes(), &decoded))
+	require.Equal(t, claims, decoded)
+
+	out.Reset()
+	require.NoError(t, writeClaims(&out, outputTable, claims))
+	require.Contains(t, out.String(), "TRACE INDEX")
+	require.Contains(t, out.String(), common.Hash{0x01}.Hex())
+
+	require.Error(t, writeClaims(&out, "yaml", claims))
+}
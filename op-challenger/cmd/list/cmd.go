@@ -0,0 +1,119 @@
+package list
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/config"
+	"github.com/ethereum-optimism/optimism/op-challenger/flags"
+	opclient "github.com/ethereum-optimism/optimism/op-service/client"
+)
+
+var (
+	OutputFlag = &cli.StringFlag{
+		Name:  "output",
+		Usage: "Output format, either table or json.",
+		Value: outputTable,
+	}
+	StatusFlag = &cli.StringFlag{
+		Name:  "status",
+		Usage: "Only list games with this status (in-progress, challenger-won, defender-won).",
+	}
+	GameTypeFlag = &cli.StringFlag{
+		Name:  "game-type",
+		Usage: "Only list games of this type (attestation, fault, validity).",
+	}
+	GameAddressFlag = &cli.StringFlag{
+		Name:     "game-address",
+		Usage:    "Address of the fault dispute game to list the claims of.",
+		Required: true,
+	}
+	DepthFlag = &cli.IntFlag{
+		Name:  "depth",
+		Usage: "Only list claims at this depth.",
+	}
+	CounteredFlag = &cli.BoolFlag{
+		Name:  "countered",
+		Usage: "Only list claims that are countered (--countered) or uncountered (--countered=false).",
+	}
+	ClaimantFlag = &cli.StringFlag{
+		Name:  "claimant",
+		Usage: "Only list claims made by this address. Requires fetching the Move events of the game.",
+	}
+	AgreementFlag = &cli.StringFlag{
+		Name:  "agreement",
+		Usage: "Only list claims the local trace agrees or disagrees with (agree, disagree). Requires --trace-alphabet.",
+	}
+	TraceAlphabetFlag = &cli.StringFlag{
+		Name:  "trace-alphabet",
+		Usage: "Alphabet to use as the local trace when comparing claims.",
+	}
+)
+
+// Commands contains the list-games and list-claims commands.
+var Commands = cli.Commands{
+	{
+		Name:  "list-games",
+		Usage: "Lists the dispute games created by the DisputeGameFactory",
+		Flags: []cli.Flag{OutputFlag, StatusFlag, GameTypeFlag},
+		Action: func(ctx *cli.Context) error {
+			filter, err := gameFilterFromCLI(ctx)
+			if err != nil {
+				return err
+			}
+			client, err := dial(ctx)
+			if err != nil {
+				return err
+			}
+			defer client.Close()
+			factory := common.HexToAddress(ctx.String(flags.DGFAddressFlag.Name))
+			if factory == (common.Address{}) {
+				return fmt.Errorf("flag %s is required", flags.DGFAddressFlag.Name)
+			}
+			games, err := fetchGames(ctx.Context, client, factory, filter)
+			if err != nil {
+				return err
+			}
+			return writeGames(os.Stdout, ctx.String(OutputFlag.Name), games)
+		},
+	},
+	{
+		Name:  "list-claims",
+		Usage: "Lists the claims of a fault dispute game",
+		Flags: []cli.Flag{OutputFlag, GameAddressFlag, DepthFlag, CounteredFlag, ClaimantFlag, AgreementFlag, TraceAlphabetFlag},
+		Action: func(ctx *cli.Context) error {
+			filter, err := claimFilterFromCLI(ctx)
+			if err != nil {
+				return err
+			}
+			logger, err := config.LoggerFromCLI(ctx)
+			if err != nil {
+				return err
+			}
+			client, err := dial(ctx)
+			if err != nil {
+				return err
+			}
+			defer client.Close()
+			game := common.HexToAddress(ctx.String(GameAddressFlag.Name))
+			claims, err := fetchClaims(ctx.Context, logger, client, game, ctx.String(TraceAlphabetFlag.Name), filter)
+			if err != nil {
+				return err
+			}
+			return writeClaims(os.Stdout, ctx.String(OutputFlag.Name), claims)
+		},
+	},
+}
+
+func dial(ctx *cli.Context) (*ethclient.Client, error) {
+	url := ctx.String(flags.L1EthRpcFlag.Name)
+	if url == "" {
+		return nil, fmt.Errorf("flag %s is required", flags.L1EthRpcFlag.Name)
+	}
+	return opclient.DialEthClientWithTimeout(context.Background(), url, opclient.DefaultDialTimeout)
+}
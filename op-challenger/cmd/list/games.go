@@ -0,0 +1,115 @@
+package list
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-challenger/types"
+)
+
+// gameInfo describes a dispute game created by the factory.
+type gameInfo struct {
+	Index     uint64         `json:"index"`
+	Address   common.Address `json:"address"`
+	Timestamp uint64         `json:"timestamp"`
+	GameType  string         `json:"gameType"`
+	Status    string         `json:"status"`
+	RootClaim common.Hash    `json:"rootClaim"`
+}
+
+// gameFilter selects the games to list. Nil fields match any game.
+type gameFilter struct {
+	status   *types.GameStatus
+	gameType *types.GameType
+}
+
+func (f gameFilter) matches(game gameInfo) bool {
+	if f.status != nil && f.status.String() != game.Status {
+		return false
+	}
+	if f.gameType != nil && f.gameType.String() != game.GameType {
+		return false
+	}
+	return true
+}
+
+func gameFilterFromCLI(ctx *cli.Context) (gameFilter, error) {
+	var filter gameFilter
+	if ctx.IsSet(StatusFlag.Name) {
+		status, err := types.GameStatusFromString(ctx.String(StatusFlag.Name))
+		if err != nil {
+			return gameFilter{}, err
+		}
+		filter.status = &status
+	}
+	if ctx.IsSet(GameTypeFlag.Name) {
+		gameType := types.NewDisputeGameType()
+		if err := gameType.Set(ctx.String(GameTypeFlag.Name)); err != nil {
+			return gameFilter{}, fmt.Errorf("invalid game type: %w", err)
+		}
+		selected := gameType.Type()
+		filter.gameType = &selected
+	}
+	return filter, nil
+}
+
+// fetchGames fetches all games created by the factory that match the filter.
+func fetchGames(ctx context.Context, client bind.ContractCaller, factoryAddr common.Address, filter gameFilter) ([]gameInfo, error) {
+	factory, err := bindings.NewDisputeGameFactoryCaller(factoryAddr, client)
+	if err != nil {
+		return nil, err
+	}
+	opts := &bind.CallOpts{Context: ctx}
+	count, err := factory.GameCount(opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch game count: %w", err)
+	}
+	var games []gameInfo
+	for i := uint64(0); i < count.Uint64(); i++ {
+		game, err := factory.GameAtIndex(opts, new(big.Int).SetUint64(i))
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch game %d: %w", i, err)
+		}
+		caller, err := bindings.NewFaultDisputeGameCaller(game.Proxy, client)
+		if err != nil {
+			return nil, err
+		}
+		gameType, err := caller.GameType(opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch type of game %v: %w", game.Proxy, err)
+		}
+		status, err := caller.Status(opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch status of game %v: %w", game.Proxy, err)
+		}
+		rootClaim, err := caller.RootClaim(opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch root claim of game %v: %w", game.Proxy, err)
+		}
+		info := gameInfo{
+			Index:     i,
+			Address:   game.Proxy,
+			Timestamp: game.Timestamp.Uint64(),
+			GameType:  gameTypeString(types.GameType(gameType)),
+			Status:    types.GameStatus(status).String(),
+			RootClaim: rootClaim,
+		}
+		if filter.matches(info) {
+			games = append(games, info)
+		}
+	}
+	return games, nil
+}
+
+func gameTypeString(gameType types.GameType) string {
+	if !gameType.Valid() {
+		return fmt.Sprintf("unknown(%d)", gameType)
+	}
+	return gameType.String()
+}
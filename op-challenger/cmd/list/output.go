@@ -0,0 +1,82 @@
+package list
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+)
+
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+)
+
+func writeGames(w io.Writer, format string, games []gameInfo) error {
+	return write(w, format, games, []string{"INDEX", "ADDRESS", "TIMESTAMP", "TYPE", "STATUS", "ROOT CLAIM"}, len(games), func(i int) []string {
+		g := games[i]
+		return []string{
+			strconv.FormatUint(g.Index, 10),
+			g.Address.Hex(),
+			strconv.FormatUint(g.Timestamp, 10),
+			g.GameType,
+			g.Status,
+			g.RootClaim.Hex(),
+		}
+	})
+}
+
+func writeClaims(w io.Writer, format string, claims []claimInfo) error {
+	return write(w, format, claims, []string{"INDEX", "PARENT", "DEPTH", "INDEX AT DEPTH", "TRACE INDEX", "VALUE", "COUNTERED", "CLAIMANT", "AGREES"}, len(claims), func(i int) []string {
+		c := claims[i]
+		claimant := "-"
+		if c.Claimant != nil {
+			claimant = c.Claimant.Hex()
+		}
+		agrees := "-"
+		if c.Agrees != nil {
+			agrees = strconv.FormatBool(*c.Agrees)
+		}
+		return []string{
+			strconv.Itoa(c.Index),
+			strconv.Itoa(c.ParentIndex),
+			strconv.Itoa(c.Depth),
+			strconv.Itoa(c.IndexAtDepth),
+			strconv.FormatUint(c.TraceIndex, 10),
+			c.Value.Hex(),
+			strconv.FormatBool(c.Countered),
+			claimant,
+			agrees,
+		}
+	})
+}
+
+// write writes the items either as indented JSON or as a table with one row per item.
+func write(w io.Writer, format string, items any, header []string, rows int, row func(i int) []string) error {
+	switch format {
+	case outputJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(items)
+	case outputTable:
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		writeRow(tw, header)
+		for i := 0; i < rows; i++ {
+			writeRow(tw, row(i))
+		}
+		return tw.Flush()
+	default:
+		return fmt.Errorf("invalid output format %q, allowed values are %s and %s", format, outputTable, outputJSON)
+	}
+}
+
+func writeRow(w io.Writer, cells []string) {
+	for i, cell := range cells {
+		if i > 0 {
+			fmt.Fprint(w, "\t")
+		}
+		fmt.Fprint(w, cell)
+	}
+	fmt.Fprintln(w)
+}
@@ -6,6 +6,12 @@ import (
 	log "github.com/ethereum/go-ethereum/log"
 	cli "github.com/urfave/cli/v2"
 
+	diff "github.com/ethereum-optimism/optimism/op-challenger/cmd/diff"
+	list "github.com/ethereum-optimism/optimism/op-challenger/cmd/list"
+	move "github.com/ethereum-optimism/optimism/op-challenger/cmd/move"
+	render "github.com/ethereum-optimism/optimism/op-challenger/cmd/render"
+	replay "github.com/ethereum-optimism/optimism/op-challenger/cmd/replay"
+	runtrace "github.com/ethereum-optimism/optimism/op-challenger/cmd/runtrace"
 	watch "github.com/ethereum-optimism/optimism/op-challenger/cmd/watch"
 	config "github.com/ethereum-optimism/optimism/op-challenger/config"
 	flags "github.com/ethereum-optimism/optimism/op-challenger/flags"
@@ -76,6 +82,8 @@ func run(args []string, action ConfigAction) error {
 			Subcommands: watch.Subcommands,
 		},
 	}
+	app.Commands = append(app.Commands, list.Commands...)
+	app.Commands = append(app.Commands, move.Command, runtrace.Command, render.Command, replay.Command, diff.Command)
 
 	return app.Run(args)
 }
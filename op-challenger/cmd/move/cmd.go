@@ -0,0 +1,210 @@
+package move
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-challenger/config"
+	"github.com/ethereum-optimism/optimism/op-challenger/fault"
+	"github.com/ethereum-optimism/optimism/op-challenger/flags"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	opclient "github.com/ethereum-optimism/optimism/op-service/client"
+	"github.com/ethereum-optimism/optimism/op-service/enum"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+)
+
+var (
+	GameAddressFlag = &cli.StringFlag{
+		Name:     "game-address",
+		Usage:    "Address of the fault dispute game to move in.",
+		Required: true,
+	}
+	ClaimIndexFlag = &cli.Uint64Flag{
+		Name:     "claim-index",
+		Usage:    "Contract index of the claim to attack or defend.",
+		Required: true,
+	}
+	AttackFlag = &cli.BoolFlag{
+		Name:  "attack",
+		Usage: "Attack the claim.",
+	}
+	DefendFlag = &cli.BoolFlag{
+		Name:  "defend",
+		Usage: "Defend the claim.",
+	}
+	TraceAlphabetFlag = &cli.StringFlag{
+		Name:     "trace-alphabet",
+		Usage:    "Alphabet to use as the trace the claim value is computed from.",
+		Required: true,
+	}
+	DryRunFlag = &cli.BoolFlag{
+		Name:  "dry-run",
+		Usage: "Print the move and its transaction data without sending it.",
+	}
+	AttackSafetyFlag = &cli.StringFlag{
+		Name:  "attack-l1-safety",
+		Usage: "Only attack claims in L1 blocks that are at least this safe. Allowed values: " + enum.EnumString(L1Safeties),
+		Value: L1SafetyLatest.String(),
+	}
+	DefendSafetyFlag = &cli.StringFlag{
+		Name:  "defend-l1-safety",
+		Usage: "Only defend claims in L1 blocks that are at least this safe. Allowed values: " + enum.EnumString(L1Safeties),
+		Value: L1SafetyLatest.String(),
+	}
+	MoveRecordFlag = &cli.StringFlag{
+		Name:  "move-record",
+		Usage: "File recording every move sent. Moves posting a different value at a position already posted at in the same game are refused.",
+	}
+	AuditLogFlag = &cli.StringFlag{
+		Name:  "audit-log",
+		Usage: "Append-only file recording the intent, transaction data, transaction hash and result of every move sent, as hash-chained JSON lines. Fails if the chain of the existing entries is broken.",
+	}
+)
+
+var (
+	ErrInvalidDirection = errors.New("exactly one of --attack and --defend must be set")
+	ErrStepNotSupported = errors.New("claims at the max game depth can only be countered with a step, which is not supported")
+)
+
+// Command lets an operator manually attack or defend a specific claim. The claim value
+// is computed from the local trace, but the operator chooses the claim to counter.
+// Steps are out of scope: the alphabet trace has no VM pre-states or proofs to step with,
+// so claims at the max game depth cannot be countered with this command.
+var Command = &cli.Command{
+	Name:        "move",
+	Usage:       "Manually attacks or defends a claim in a fault dispute game",
+	Description: "Steps against claims at the max game depth are not supported, since the trace has no VM pre-states or proofs to step with.",
+	Flags:       []cli.Flag{GameAddressFlag, ClaimIndexFlag, AttackFlag, DefendFlag, TraceAlphabetFlag, DryRunFlag, AttackSafetyFlag, DefendSafetyFlag, MoveRecordFlag, AuditLogFlag},
+	Action: func(ctx *cli.Context) error {
+		logger, err := config.LoggerFromCLI(ctx)
+		if err != nil {
+			return err
+		}
+		if ctx.Bool(AttackFlag.Name) == ctx.Bool(DefendFlag.Name) {
+			return ErrInvalidDirection
+		}
+		return move(ctx, logger)
+	},
+}
+
+// move computes the requested move and sends it, unless this is a dry run.
+func move(cliCtx *cli.Context, logger log.Logger) error {
+	ctx := cliCtx.Context
+	l1Client, err := opclient.DialEthClientWithTimeout(ctx, cliCtx.String(flags.L1EthRpcFlag.Name), opclient.DefaultDialTimeout)
+	if err != nil {
+		return fmt.Errorf("failed to dial L1: %w", err)
+	}
+	defer l1Client.Close()
+
+	attack := cliCtx.Bool(AttackFlag.Name)
+	safetyFlag := DefendSafetyFlag
+	if attack {
+		safetyFlag = AttackSafetyFlag
+	}
+	safety, err := L1SafetyFromString(cliCtx.String(safetyFlag.Name))
+	if err != nil {
+		return err
+	}
+
+	gameAddr := common.HexToAddress(cliCtx.String(GameAddressFlag.Name))
+	response, err := computeMove(ctx, l1Client, gameAddr, cliCtx.String(TraceAlphabetFlag.Name), cliCtx.Uint64(ClaimIndexFlag.Name), attack, safety)
+	if err != nil {
+		return err
+	}
+	logger = logger.New("game", gameAddr, "parent_index", response.ParentContractIndex, "is_defend", response.DefendsParent(),
+		"depth", response.Depth(), "index_at_depth", response.IndexAtDepth(), "value", response.Value)
+
+	if cliCtx.Bool(DryRunFlag.Name) {
+		responder, err := fault.NewFaultResponder(logger, nil, gameAddr)
+		if err != nil {
+			return err
+		}
+		txData, err := responder.BuildTx(ctx, *response)
+		if err != nil {
+			return err
+		}
+		logger.Info("Dry run, not sending move", "to", gameAddr, "tx_data", hexutil.Encode(txData))
+		return nil
+	}
+
+	cfg, err := config.NewConfigFromCLI(cliCtx)
+	if err != nil {
+		return err
+	}
+	txMgr, err := txmgr.NewSimpleTxManager("challenger", logger, metrics.NewMetrics("default"), *cfg.TxMgrConfig)
+	if err != nil {
+		return err
+	}
+	faultResponder, err := fault.NewFaultResponder(logger, txMgr, gameAddr)
+	if err != nil {
+		return err
+	}
+	var responder fault.Responder = faultResponder
+	if path := cliCtx.String(AuditLogFlag.Name); path != "" {
+		auditLog, err := fault.OpenTxAuditLog(path)
+		if err != nil {
+			return err
+		}
+		defer auditLog.Close()
+		responder = fault.NewAuditedResponder(faultResponder, auditLog, gameAddr)
+	}
+	if path := cliCtx.String(MoveRecordFlag.Name); path != "" {
+		record, err := fault.OpenMoveRecord(path)
+		if err != nil {
+			return err
+		}
+		defer record.Close()
+		responder = fault.NewGuardedResponder(responder, record, gameAddr)
+	}
+	logger.Info("Sending move")
+	return responder.Respond(ctx, *response)
+}
+
+// l1Client is the subset of [ethclient.Client] used to compute a move.
+type l1Client interface {
+	bind.ContractCaller
+	fault.HeaderFetcher
+}
+
+// computeMove loads the claims of the game and computes the counter to the claim at claimIndex.
+// The claims are read at the latest block of the given L1 safety, so claims in less safe blocks
+// are not visible and cannot be countered.
+func computeMove(ctx context.Context, client l1Client, gameAddr common.Address, alphabet string, claimIndex uint64, attack bool, safety L1Safety) (*fault.Claim, error) {
+	caller, err := bindings.NewFaultDisputeGameCaller(gameAddr, client)
+	if err != nil {
+		return nil, err
+	}
+	block, err := blockNumber(ctx, client, safety)
+	if err != nil {
+		return nil, err
+	}
+	opts := &bind.CallOpts{Context: ctx, BlockNumber: block}
+	maxDepth, err := caller.MAXGAMEDEPTH(opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch max game depth: %w", err)
+	}
+	claims, err := fault.NewLoader(caller).FetchClaimsWithOpts(opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := fault.ValidateClaims(claims, int(maxDepth.Uint64())); err != nil {
+		return nil, err
+	}
+	if claimIndex >= uint64(len(claims)) {
+		return nil, fmt.Errorf("claim index %d out of range, game has %d claims in %v L1 blocks", claimIndex, len(claims), safety)
+	}
+	solver := fault.NewSolver(int(maxDepth.Uint64()), fault.NewAlphabetProvider(alphabet, maxDepth.Uint64()))
+	response, err := solver.Counter(claims[claimIndex], attack)
+	if errors.Is(err, fault.ErrGameDepthReached) {
+		return nil, fmt.Errorf("%w: claim %d", ErrStepNotSupported, claimIndex)
+	}
+	return response, err
+}
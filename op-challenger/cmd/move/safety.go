@@ -0,0 +1,59 @@
+package move
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/fault"
+	"github.com/ethereum-optimism/optimism/op-service/enum"
+)
+
+// L1Safety is how safe the L1 block holding a claim must be before the claim is acted on.
+type L1Safety string
+
+const (
+	L1SafetyLatest    L1Safety = "latest"
+	L1SafetySafe      L1Safety = "safe"
+	L1SafetyFinalized L1Safety = "finalized"
+)
+
+// L1Safeties are the supported L1 safety levels.
+var L1Safeties = []L1Safety{L1SafetyLatest, L1SafetySafe, L1SafetyFinalized}
+
+func (s L1Safety) String() string {
+	return string(s)
+}
+
+// L1SafetyFromString parses an L1 safety level.
+func L1SafetyFromString(value string) (L1Safety, error) {
+	for _, safety := range L1Safeties {
+		if safety.String() == value {
+			return safety, nil
+		}
+	}
+	return "", fmt.Errorf("unknown L1 safety %q, allowed values are %s", value, enum.EnumString(L1Safeties))
+}
+
+// blockNumber returns the number of the latest L1 block at the safety level,
+// or nil to read the latest state.
+func blockNumber(ctx context.Context, headers fault.HeaderFetcher, safety L1Safety) (*big.Int, error) {
+	var tag rpc.BlockNumber
+	switch safety {
+	case L1SafetyLatest:
+		return nil, nil
+	case L1SafetySafe:
+		tag = rpc.SafeBlockNumber
+	case L1SafetyFinalized:
+		tag = rpc.FinalizedBlockNumber
+	default:
+		return nil, fmt.Errorf("unknown L1 safety %q", safety)
+	}
+	header, err := headers.HeaderByNumber(ctx, big.NewInt(int64(tag)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %v L1 block: %w", safety, err)
+	}
+	return header.Number, nil
+}
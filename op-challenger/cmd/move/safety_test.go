@@ -0,0 +1,56 @@
+package move
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+type mockHeaderFetcher struct {
+	requested *big.Int
+	err       error
+}
+
+func (m *mockHeaderFetcher) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	m.requested = number
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &types.Header{Number: big.NewInt(100)}, nil
+}
+
+func TestL1SafetyFromString(t *testing.T) {
+	for _, safety := range L1Safeties {
+		parsed, err := L1SafetyFromString(safety.String())
+		require.NoError(t, err)
+		require.Equal(t, safety, parsed)
+	}
+	_, err := L1SafetyFromString("unsafe")
+	require.ErrorContains(t, err, "allowed values are latest, safe, finalized")
+}
+
+func TestBlockNumber(t *testing.T) {
+	headers := &mockHeaderFetcher{}
+	number, err := blockNumber(context.Background(), headers, L1SafetyLatest)
+	require.NoError(t, err)
+	require.Nil(t, number)
+	require.Nil(t, headers.requested, "the latest state needs no block lookup")
+
+	number, err = blockNumber(context.Background(), headers, L1SafetySafe)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(100), number)
+	require.Equal(t, big.NewInt(int64(rpc.SafeBlockNumber)), headers.requested)
+
+	_, err = blockNumber(context.Background(), headers, L1SafetyFinalized)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(int64(rpc.FinalizedBlockNumber)), headers.requested)
+
+	headers.err = errors.New("boom")
+	_, err = blockNumber(context.Background(), headers, L1SafetyFinalized)
+	require.ErrorContains(t, err, "failed to fetch finalized L1 block")
+}
@@ -0,0 +1,82 @@
+package render
+
+import (
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-challenger/challenger"
+	"github.com/ethereum-optimism/optimism/op-challenger/config"
+	"github.com/ethereum-optimism/optimism/op-challenger/fault"
+	"github.com/ethereum-optimism/optimism/op-challenger/flags"
+	opclient "github.com/ethereum-optimism/optimism/op-service/client"
+)
+
+var (
+	GameAddressFlag = &cli.StringFlag{
+		Name:     "game-address",
+		Usage:    "Address of the fault dispute game to render.",
+		Required: true,
+	}
+	FormatFlag = &cli.StringFlag{
+		Name:  "format",
+		Usage: "Output format, either dot or mermaid.",
+		Value: string(fault.RenderDOT),
+	}
+	TraceAlphabetFlag = &cli.StringFlag{
+		Name:  "trace-alphabet",
+		Usage: "Alphabet to use as the local trace to highlight honest claims with.",
+	}
+)
+
+// Command renders the claim tree of a fault dispute game as a graph.
+var Command = &cli.Command{
+	Name:  "render-game",
+	Usage: "Renders the claim tree of a fault dispute game to GraphViz DOT or Mermaid",
+	Flags: []cli.Flag{GameAddressFlag, FormatFlag, TraceAlphabetFlag},
+	Action: func(ctx *cli.Context) error {
+		logger, err := config.LoggerFromCLI(ctx)
+		if err != nil {
+			return err
+		}
+		l1Client, err := opclient.DialEthClientWithTimeout(ctx.Context, ctx.String(flags.L1EthRpcFlag.Name), opclient.DefaultDialTimeout)
+		if err != nil {
+			return fmt.Errorf("failed to dial L1: %w", err)
+		}
+		defer l1Client.Close()
+
+		game, err := bindings.NewFaultDisputeGame(common.HexToAddress(ctx.String(GameAddressFlag.Name)), l1Client)
+		if err != nil {
+			return err
+		}
+		// Read the game at a single block, so that the claims are consistent with each other.
+		// The claimants of the claims are taken from the Move events up to that block.
+		var maxDepth *big.Int
+		var claims []fault.Claim
+		reader := challenger.NewPinnedReader(l1Client, logger, challenger.DefaultPinnedReadAttempts)
+		_, err = reader.Read(ctx.Context, func(opts *bind.CallOpts) error {
+			maxDepth, err = game.MAXGAMEDEPTH(opts)
+			if err != nil {
+				return fmt.Errorf("failed to fetch max game depth: %w", err)
+			}
+			claims, err = fault.NewLoader(&game.FaultDisputeGameCaller).FetchAnnotatedClaims(opts, &game.FaultDisputeGameFilterer)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		if err := fault.ValidateClaims(claims, int(maxDepth.Uint64())); err != nil {
+			return err
+		}
+		var trace fault.TraceProvider
+		if alphabet := ctx.String(TraceAlphabetFlag.Name); alphabet != "" {
+			trace = fault.NewAlphabetProvider(alphabet, maxDepth.Uint64())
+		}
+		return fault.Render(os.Stdout, fault.RenderFormat(ctx.String(FormatFlag.Name)), claims, int(maxDepth.Uint64()), trace)
+	},
+}
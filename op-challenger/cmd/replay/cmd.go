@@ -0,0 +1,148 @@
+package replay
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"text/tabwriter"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-challenger/challenger"
+	"github.com/ethereum-optimism/optimism/op-challenger/config"
+	"github.com/ethereum-optimism/optimism/op-challenger/fault"
+	"github.com/ethereum-optimism/optimism/op-challenger/flags"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	opclient "github.com/ethereum-optimism/optimism/op-service/client"
+)
+
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+)
+
+var (
+	GameAddressFlag = &cli.StringFlag{
+		Name:     "game-address",
+		Usage:    "Address of the fault dispute game to replay.",
+		Required: true,
+	}
+	BlockFlag = &cli.Uint64Flag{
+		Name:  "block",
+		Usage: "L1 block to reconstruct the game at. Requires an archive node for old blocks. Defaults to the latest block.",
+	}
+	TraceAlphabetFlag = &cli.StringFlag{
+		Name:     "trace-alphabet",
+		Usage:    "Alphabet to use as the trace the solver plays with.",
+		Required: true,
+	}
+	OutputFlag = &cli.StringFlag{
+		Name:  "output",
+		Usage: "Output format, either table or json.",
+		Value: outputTable,
+	}
+	TraceAuditSamplesFlag = &cli.IntFlag{
+		Name:  "trace-audit-samples",
+		Usage: "Number of trace values used by the solver to re-derive after the replay. Fails if any of them changed. Zero disables the audit.",
+	}
+)
+
+// Command reconstructs a game as of a historical block and prints the actions the
+// current solver would have taken against it, without sending anything.
+var Command = &cli.Command{
+	Name:  "replay",
+	Usage: "Prints the actions the solver would take in a fault dispute game at a given block",
+	Flags: []cli.Flag{GameAddressFlag, BlockFlag, TraceAlphabetFlag, OutputFlag, TraceAuditSamplesFlag},
+	Action: func(ctx *cli.Context) error {
+		format := ctx.String(OutputFlag.Name)
+		if format != outputTable && format != outputJSON {
+			return fmt.Errorf("unknown output format %q", format)
+		}
+		logger, err := config.LoggerFromCLI(ctx)
+		if err != nil {
+			return err
+		}
+		l1Client, err := opclient.DialEthClientWithTimeout(ctx.Context, ctx.String(flags.L1EthRpcFlag.Name), opclient.DefaultDialTimeout)
+		if err != nil {
+			return fmt.Errorf("failed to dial L1: %w", err)
+		}
+		defer l1Client.Close()
+
+		game, err := bindings.NewFaultDisputeGame(common.HexToAddress(ctx.String(GameAddressFlag.Name)), l1Client)
+		if err != nil {
+			return err
+		}
+		var maxDepth *big.Int
+		var claims []fault.Claim
+		read := func(opts *bind.CallOpts) error {
+			maxDepth, err = game.MAXGAMEDEPTH(opts)
+			if err != nil {
+				return fmt.Errorf("failed to fetch max game depth: %w", err)
+			}
+			claims, err = fault.NewLoader(&game.FaultDisputeGameCaller).FetchAnnotatedClaims(opts, &game.FaultDisputeGameFilterer)
+			return err
+		}
+		if ctx.IsSet(BlockFlag.Name) {
+			err = read(&bind.CallOpts{Context: ctx.Context, BlockNumber: new(big.Int).SetUint64(ctx.Uint64(BlockFlag.Name))})
+		} else {
+			// Read the game at the latest block, so that the claims are consistent with each other.
+			_, err = challenger.NewPinnedReader(l1Client, logger, challenger.DefaultPinnedReadAttempts).Read(ctx.Context, read)
+		}
+		if err != nil {
+			return err
+		}
+		if err := fault.ValidateClaims(claims, int(maxDepth.Uint64())); err != nil {
+			return err
+		}
+		trace := fault.NewAuditingTraceProvider(fault.NewAlphabetProvider(ctx.String(TraceAlphabetFlag.Name), maxDepth.Uint64()), logger, metrics.NoopMetrics)
+		solver := fault.NewSolver(int(maxDepth.Uint64()), trace)
+		if err := writeActions(os.Stdout, format, fault.Replay(claims, solver)); err != nil {
+			return err
+		}
+		if samples := ctx.Int(TraceAuditSamplesFlag.Name); samples > 0 {
+			return trace.Audit(samples)
+		}
+		return nil
+	},
+}
+
+func writeActions(w io.Writer, format string, actions []fault.ReplayAction) error {
+	if format == outputJSON {
+		data, err := fault.EncodeActionsJSON(actions)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(data))
+		return err
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "CLAIM\tDEPTH\tINDEX AT DEPTH\tL1 BLOCK\tTX\tREASON\tACTION\tRESPONSE")
+	for _, action := range actions {
+		claim := action.Claim
+		kind, response := "none", "-"
+		if move := action.Decision.Move; move != nil {
+			kind = "attack"
+			if move.DefendsParent() {
+				kind = "defend"
+			}
+			if action.Duplicate {
+				kind += " (exists)"
+			}
+			response = fmt.Sprintf("d%d i%d %v", move.Depth(), move.IndexAtDepth(), move.Value)
+		}
+		if action.Err != nil {
+			kind = "error: " + action.Err.Error()
+		}
+		// The root claim is created with the game and does not have a Move event.
+		l1Block, tx := "-", "-"
+		if claim.Provenance != (fault.Provenance{}) {
+			l1Block, tx = fmt.Sprint(claim.Provenance.L1Block), claim.Provenance.TxHash.Hex()
+		}
+		fmt.Fprintf(tw, "%d\t%d\t%d\t%s\t%s\t%s\t%s\t%s\n", claim.ContractIndex, claim.Depth(), claim.IndexAtDepth(), l1Block, tx, action.Decision.Reason, kind, response)
+	}
+	return tw.Flush()
+}
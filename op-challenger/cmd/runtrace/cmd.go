@@ -0,0 +1,174 @@
+package runtrace
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-challenger/challenger"
+	"github.com/ethereum-optimism/optimism/op-challenger/config"
+	"github.com/ethereum-optimism/optimism/op-challenger/fault"
+	"github.com/ethereum-optimism/optimism/op-challenger/flags"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	opclient "github.com/ethereum-optimism/optimism/op-service/client"
+)
+
+var (
+	GameAddressFlag = &cli.StringFlag{
+		Name:     "game-address",
+		Usage:    "Address of the dispute game to verify the root claim of.",
+		Required: true,
+	}
+	TraceAlphabetFlag = &cli.StringFlag{
+		Name:  "trace-alphabet",
+		Usage: "Alphabet to use as the trace. If not set, the output root at the game's L2 block is fetched from the rollup node.",
+	}
+	TraceAuditSamplesFlag = &cli.IntFlag{
+		Name:  "trace-audit-samples",
+		Usage: "Number of computed trace values to re-derive after computing the root. Fails if any of them changed. Zero disables the audit.",
+	}
+)
+
+// ErrRootClaimMismatch is returned when the locally computed root does not match the root claim.
+var ErrRootClaimMismatch = errors.New("root claim does not match the local trace")
+
+// Command computes the root of a game locally and compares it with the on-chain root claim,
+// without participating in the game.
+var Command = &cli.Command{
+	Name:  "run-trace",
+	Usage: "Verifies the root claim of a dispute game against the local trace",
+	Flags: []cli.Flag{GameAddressFlag, TraceAlphabetFlag, TraceAuditSamplesFlag},
+	Action: func(ctx *cli.Context) error {
+		logger, err := config.LoggerFromCLI(ctx)
+		if err != nil {
+			return err
+		}
+		l1Client, err := opclient.DialEthClientWithTimeout(ctx.Context, ctx.String(flags.L1EthRpcFlag.Name), opclient.DefaultDialTimeout)
+		if err != nil {
+			return fmt.Errorf("failed to dial L1: %w", err)
+		}
+		defer l1Client.Close()
+		gameAddr := common.HexToAddress(ctx.String(GameAddressFlag.Name))
+		game, err := bindings.NewFaultDisputeGameCaller(gameAddr, l1Client)
+		if err != nil {
+			return err
+		}
+
+		var source rootSource
+		if alphabet := ctx.String(TraceAlphabetFlag.Name); alphabet != "" {
+			source = alphabetRoot(alphabet)
+		} else {
+			rollupClient, err := opclient.DialRollupClientWithTimeout(ctx.Context, ctx.String(flags.RollupRpcFlag.Name), opclient.DefaultDialTimeout)
+			if err != nil {
+				return fmt.Errorf("failed to dial rollup node: %w", err)
+			}
+			defer rollupClient.Close()
+			source = outputRoot(rollupClient)
+		}
+
+		result, err := verifyRoot(ctx.Context, logger, game, source, ctx.Int(TraceAuditSamplesFlag.Name))
+		if err != nil {
+			return err
+		}
+		logger.Info("Computed game root", "game", gameAddr, "l2_block", result.L2BlockNumber,
+			"root_claim", result.RootClaim, "computed", result.Computed, "matches", result.Matches())
+		if !result.Matches() {
+			return fmt.Errorf("%w: claimed %v, computed %v", ErrRootClaimMismatch, result.RootClaim, result.Computed)
+		}
+		return nil
+	},
+}
+
+// GameCaller is the subset of [bindings.FaultDisputeGameCaller] needed to verify a root claim.
+type GameCaller interface {
+	RootClaim(opts *bind.CallOpts) ([32]byte, error)
+	MAXGAMEDEPTH(opts *bind.CallOpts) (*big.Int, error)
+	L2BlockNumber(opts *bind.CallOpts) (*big.Int, error)
+}
+
+// rootSource computes the expected root of a game with the given max depth and L2 block number.
+type rootSource func(ctx context.Context, maxDepth uint64, l2BlockNumber uint64) (common.Hash, error)
+
+// alphabetRoot computes the root from an [fault.AlphabetProvider] trace.
+func alphabetRoot(alphabet string) rootSource {
+	return func(ctx context.Context, maxDepth uint64, l2BlockNumber uint64) (common.Hash, error) {
+		root := fault.NewPosition(0, 0)
+		return fault.NewAlphabetProvider(alphabet, maxDepth).Get(root.TraceIndex(int(maxDepth)))
+	}
+}
+
+// outputRoot fetches the output root at the game's L2 block from the rollup node.
+func outputRoot(rollupClient challenger.OutputAPI) rootSource {
+	return func(ctx context.Context, maxDepth uint64, l2BlockNumber uint64) (common.Hash, error) {
+		output, err := rollupClient.OutputAtBlock(ctx, l2BlockNumber)
+		if err != nil {
+			return common.Hash{}, fmt.Errorf("failed to fetch output at block %d: %w", l2BlockNumber, err)
+		}
+		return common.Hash(output.OutputRoot), nil
+	}
+}
+
+type rootResult struct {
+	L2BlockNumber uint64
+	RootClaim     common.Hash
+	Computed      common.Hash
+}
+
+func (r rootResult) Matches() bool {
+	return r.RootClaim == r.Computed
+}
+
+// rootTrace is a [fault.TraceProvider] returning the root computed by a rootSource at every trace index,
+// so that the computed root can be audited.
+type rootTrace struct {
+	ctx           context.Context
+	source        rootSource
+	maxDepth      uint64
+	l2BlockNumber uint64
+}
+
+func (r rootTrace) Get(i uint64) (common.Hash, error) {
+	return r.source(r.ctx, r.maxDepth, r.l2BlockNumber)
+}
+
+// verifyRoot computes the root of the game from the source. If auditSamples is positive,
+// the root is computed again and an error is returned if it changed.
+func verifyRoot(ctx context.Context, logger log.Logger, game GameCaller, source rootSource, auditSamples int) (rootResult, error) {
+	opts := &bind.CallOpts{Context: ctx}
+	rootClaim, err := game.RootClaim(opts)
+	if err != nil {
+		return rootResult{}, fmt.Errorf("failed to fetch root claim: %w", err)
+	}
+	maxDepth, err := game.MAXGAMEDEPTH(opts)
+	if err != nil {
+		return rootResult{}, fmt.Errorf("failed to fetch max game depth: %w", err)
+	}
+	l2BlockNumber, err := game.L2BlockNumber(opts)
+	if err != nil {
+		return rootResult{}, fmt.Errorf("failed to fetch L2 block number: %w", err)
+	}
+	rt := rootTrace{ctx: ctx, source: source, maxDepth: maxDepth.Uint64(), l2BlockNumber: l2BlockNumber.Uint64()}
+	trace := fault.NewAuditingTraceProvider(rt, logger, metrics.NoopMetrics)
+	root := fault.NewPosition(0, 0)
+	computed, err := trace.Get(root.TraceIndex(int(maxDepth.Uint64())))
+	if err != nil {
+		return rootResult{}, err
+	}
+	if auditSamples > 0 {
+		if err := trace.Audit(auditSamples); err != nil {
+			return rootResult{}, err
+		}
+	}
+	return rootResult{
+		L2BlockNumber: l2BlockNumber.Uint64(),
+		RootClaim:     rootClaim,
+		Computed:      computed,
+	}, nil
+}
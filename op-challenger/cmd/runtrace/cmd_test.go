@@ -0,0 +1,83 @@
+package runtrace
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/fault"
+	"github.com/ethereum-optimism/optimism/op-node/eth"
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+)
+
+type mockGameCaller struct {
+	rootClaim common.Hash
+}
+
+func (m *mockGameCaller) RootClaim(opts *bind.CallOpts) ([32]byte, error) {
+	return m.rootClaim, nil
+}
+
+func (m *mockGameCaller) MAXGAMEDEPTH(opts *bind.CallOpts) (*big.Int, error) {
+	return big.NewInt(3), nil
+}
+
+func (m *mockGameCaller) L2BlockNumber(opts *bind.CallOpts) (*big.Int, error) {
+	return big.NewInt(100), nil
+}
+
+type mockOutputAPI struct {
+	root  eth.Bytes32
+	calls int
+	// changes is set to make the output root change after the first call.
+	changes bool
+}
+
+func (m *mockOutputAPI) OutputAtBlock(ctx context.Context, blockNum uint64) (*eth.OutputResponse, error) {
+	m.calls++
+	root := m.root
+	if m.changes && m.calls > 1 {
+		root[0]++
+	}
+	return &eth.OutputResponse{OutputRoot: root}, nil
+}
+
+// TestVerifyRoot_Alphabet tests verifying the root claim against an alphabet trace.
+func TestVerifyRoot_Alphabet(t *testing.T) {
+	expected := fault.NewAlphabetProvider("abcdefgh", 3).ComputeAlphabetClaim(7)
+
+	result, err := verifyRoot(context.Background(), testlog.Logger(t, log.LvlError), &mockGameCaller{rootClaim: expected}, alphabetRoot("abcdefgh"), 1)
+	require.NoError(t, err)
+	require.True(t, result.Matches())
+
+	result, err = verifyRoot(context.Background(), testlog.Logger(t, log.LvlError), &mockGameCaller{rootClaim: expected}, alphabetRoot("abcdexyz"), 0)
+	require.NoError(t, err)
+	require.False(t, result.Matches())
+}
+
+// TestVerifyRoot_OutputRoot tests verifying the root claim against the rollup node output root.
+func TestVerifyRoot_OutputRoot(t *testing.T) {
+	root := common.Hash{0xaa}
+	result, err := verifyRoot(context.Background(), testlog.Logger(t, log.LvlError), &mockGameCaller{rootClaim: root}, outputRoot(&mockOutputAPI{root: eth.Bytes32(root)}), 0)
+	require.NoError(t, err)
+	require.True(t, result.Matches())
+	require.Equal(t, uint64(100), result.L2BlockNumber)
+}
+
+// TestVerifyRoot_Audit tests that a root that changes when re-derived fails the audit.
+func TestVerifyRoot_Audit(t *testing.T) {
+	root := common.Hash{0xaa}
+	api := &mockOutputAPI{root: eth.Bytes32(root)}
+	_, err := verifyRoot(context.Background(), testlog.Logger(t, log.LvlCrit), &mockGameCaller{rootClaim: root}, outputRoot(api), 1)
+	require.NoError(t, err)
+	require.Equal(t, 2, api.calls)
+
+	api = &mockOutputAPI{root: eth.Bytes32(root), changes: true}
+	_, err = verifyRoot(context.Background(), testlog.Logger(t, log.LvlCrit), &mockGameCaller{rootClaim: root}, outputRoot(api), 1)
+	require.ErrorIs(t, err, fault.ErrNondeterministicTrace)
+}
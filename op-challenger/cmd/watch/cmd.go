@@ -1,11 +1,24 @@
 package watch
 
 import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/urfave/cli/v2"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/config"
+	"github.com/ethereum-optimism/optimism/op-challenger/flags"
+	"github.com/ethereum-optimism/optimism/op-node/client"
+	"github.com/ethereum-optimism/optimism/op-node/sources"
+	opclient "github.com/ethereum-optimism/optimism/op-service/client"
 )
 
+var GameAddressFlag = &cli.StringFlag{
+	Name:     "game-address",
+	Usage:    "Address of the fault dispute game to watch.",
+	Required: true,
+}
+
 var Subcommands = cli.Commands{
 	{
 		Name:  "oracle",
@@ -43,4 +56,31 @@ var Subcommands = cli.Commands{
 			return Factory(logger, cfg)
 		},
 	},
+	{
+		Name:  "game",
+		Usage: "Follows the claims of a fault dispute game",
+		Flags: []cli.Flag{GameAddressFlag},
+		Action: func(ctx *cli.Context) error {
+			logger, err := config.LoggerFromCLI(ctx)
+			if err != nil {
+				return err
+			}
+			l1Client, err := opclient.DialEthClientWithTimeout(ctx.Context, ctx.String(flags.L1EthRpcFlag.Name), opclient.DefaultDialTimeout)
+			if err != nil {
+				return fmt.Errorf("failed to dial L1: %w", err)
+			}
+			defer l1Client.Close()
+			l1RPC, err := client.NewRPC(ctx.Context, logger, ctx.String(flags.L1EthRpcFlag.Name))
+			if err != nil {
+				return fmt.Errorf("failed to dial L1: %w", err)
+			}
+			defer l1RPC.Close()
+			l1Source, err := sources.NewL1Client(l1RPC, logger, nil, l1SourceConfig)
+			if err != nil {
+				return err
+			}
+
+			return Game(ctx.Context, logger, l1Client, l1Source, common.HexToAddress(ctx.String(GameAddressFlag.Name)))
+		},
+	},
 }
@@ -0,0 +1,169 @@
+package watch
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-challenger/fault"
+	"github.com/ethereum-optimism/optimism/op-node/eth"
+	"github.com/ethereum-optimism/optimism/op-node/sources"
+	opclient "github.com/ethereum-optimism/optimism/op-service/client"
+)
+
+// reorgCheckInterval is how often the claims of a watched game are checked for L1 reorgs.
+const reorgCheckInterval = time.Minute
+
+// l1SourceCacheSize is the number of recent L1 blocks whose headers and receipts are cached.
+const l1SourceCacheSize = 256
+
+// l1SourceConfig configures the L1 source of a watched game, which fetches the blocks the claims
+// of the game are in with batched requests. The blocks are cached by hash, so repeated reorg checks
+// and reports of claims in the same recent blocks do not fetch them again.
+var l1SourceConfig = &sources.L1ClientConfig{
+	EthClientConfig: sources.EthClientConfig{
+		ReceiptsCacheSize:     l1SourceCacheSize,
+		TransactionsCacheSize: l1SourceCacheSize,
+		HeadersCacheSize:      l1SourceCacheSize,
+		MaxRequestsPerBatch:   20,
+		MaxConcurrentRequests: 10,
+		RPCProviderKind:       sources.RPCKindBasic,
+		MethodResetDuration:   time.Minute,
+	},
+	L1BlockRefsCacheSize: l1SourceCacheSize,
+}
+
+// gameHeaders serves the headers of a watched game. The latest block is looked up with the
+// ethclient, while the blocks of the claims are checked for reorgs as batched ranges of the L1 source.
+type gameHeaders struct {
+	*ethclient.Client
+	source *sources.L1Client
+}
+
+func (h *gameHeaders) L1BlockRefsByRange(ctx context.Context, start uint64, end uint64) ([]eth.L1BlockRef, error) {
+	return h.source.L1BlockRefsByRange(ctx, start, end)
+}
+
+// Game follows the claims of a fault dispute game. The claims are loaded once and then
+// updated from the events of the game, until interrupted. The game is reloaded if claims are reorged out.
+// The outcome and cost of the transactions of the events are reported from their receipts.
+func Game(ctx context.Context, logger log.Logger, l1Client *ethclient.Client, l1Source *sources.L1Client, gameAddr common.Address) error {
+	game, err := bindings.NewFaultDisputeGame(gameAddr, l1Client)
+	if err != nil {
+		return err
+	}
+	maxDepth, err := game.MAXGAMEDEPTH(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return fmt.Errorf("failed to fetch max game depth: %w", err)
+	}
+	tracker := fault.NewGameTracker(logger, gameAddr, int(maxDepth.Uint64()), fault.NewLoader(&game.FaultDisputeGameCaller), &game.FaultDisputeGameFilterer, &gameHeaders{Client: l1Client, source: l1Source})
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	logs := make(chan types.Log)
+	sub, err := opclient.SubscribeLogs(ctx, logger, l1Client, ethereum.FilterQuery{Addresses: []common.Address{gameAddr}}, logs)
+	if err != nil {
+		return fmt.Errorf("failed to subscribe to the game: %w", err)
+	}
+	defer sub.Unsubscribe()
+
+	// Load the game after subscribing, so that no claims are missed in between.
+	if err := tracker.Load(ctx); err != nil {
+		return err
+	}
+	logger.Info("Loaded game", "game", gameAddr, "claims", len(tracker.Game().Claims()))
+
+	interruptChannel := make(chan os.Signal, 1)
+	signal.Notify(interruptChannel, []os.Signal{
+		os.Interrupt,
+		os.Kill,
+		syscall.SIGTERM,
+		syscall.SIGQUIT,
+	}...)
+
+	ticker := time.NewTicker(reorgCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if _, err := tracker.CheckReorgs(ctx); err != nil {
+				logger.Error("Failed to check game for reorgs", "err", err)
+			}
+		case l := <-logs:
+			batch := append([]types.Log{l}, pendingLogs(logs)...)
+			if err := tracker.ApplyLogs(ctx, batch); err != nil {
+				logger.Error("Failed to update game", "err", err)
+				continue
+			}
+			logger.Info("Updated game", "claims", len(tracker.Game().Claims()), "logs", len(batch), "l1_block", batch[len(batch)-1].BlockNumber)
+			reportTransactions(ctx, logger, l1Source, batch)
+		case err := <-sub.Err():
+			return err
+		case <-interruptChannel:
+			logger.Info("Received interrupt signal, exiting...")
+			return nil
+		}
+	}
+}
+
+// pendingLogs returns the logs that are already waiting in the channel, without blocking,
+// so that logs which arrive together are applied and reported together.
+func pendingLogs(logs <-chan types.Log) []types.Log {
+	var out []types.Log
+	for {
+		select {
+		case l := <-logs:
+			out = append(out, l)
+		default:
+			return out
+		}
+	}
+}
+
+// reportTransactions logs the outcome and cost of the transactions that emitted the logs.
+// The receipts of all their blocks are fetched with batched requests.
+func reportTransactions(ctx context.Context, logger log.Logger, l1Source *sources.L1Client, logs []types.Log) {
+	var blocks []common.Hash
+	seenBlocks := make(map[common.Hash]bool)
+	for _, l := range logs {
+		if l.Removed || seenBlocks[l.BlockHash] {
+			continue
+		}
+		seenBlocks[l.BlockHash] = true
+		blocks = append(blocks, l.BlockHash)
+	}
+	if len(blocks) == 0 {
+		return
+	}
+	_, blockReceipts, err := l1Source.FetchReceiptsByHashes(ctx, blocks)
+	if err != nil {
+		logger.Warn("Failed to fetch receipts of game transactions", "err", err)
+		return
+	}
+	receipts := make(map[common.Hash]*types.Receipt)
+	for _, rs := range blockReceipts {
+		for _, r := range rs {
+			receipts[r.TxHash] = r
+		}
+	}
+	seenTxs := make(map[common.Hash]bool)
+	for _, l := range logs {
+		r, ok := receipts[l.TxHash]
+		if !ok || seenTxs[l.TxHash] {
+			continue
+		}
+		seenTxs[l.TxHash] = true
+		logger.Info("Game transaction", "tx", l.TxHash, "l1_block", l.BlockNumber, "status", r.Status, "gas_used", r.GasUsed, "gas_price", r.EffectiveGasPrice)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/urfave/cli/v2"
 
 	flags "github.com/ethereum-optimism/optimism/op-challenger/flags"
+	challengerrpc "github.com/ethereum-optimism/optimism/op-challenger/rpc"
 
 	opservice "github.com/ethereum-optimism/optimism/op-service"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
@@ -28,6 +29,9 @@ var (
 	ErrMissingLogConfig      = errors.New("missing log config")
 	ErrMissingMetricsConfig  = errors.New("missing metrics config")
 	ErrMissingPprofConfig    = errors.New("missing pprof config")
+	ErrInvalidMaxLatency     = errors.New("invalid l1 eth rpc max latency")
+	ErrInvalidMaxErrorRate   = errors.New("invalid l1 eth rpc max error rate")
+	ErrInvalidRateLimit      = errors.New("invalid l1 eth rpc rate limit")
 )
 
 // Config is a well typed config that is parsed from the CLI params.
@@ -37,6 +41,21 @@ type Config struct {
 	// L1EthRpc is the HTTP provider URL for L1.
 	L1EthRpc string
 
+	// L1EthRpcFallbacks are the provider URLs for L1 that contract reads fail over to.
+	L1EthRpcFallbacks []string
+
+	// L1EthRpcMaxLatency is the 90th percentile latency above which an L1 provider is demoted
+	// behind the others when fallbacks are configured. Zero disables the check.
+	L1EthRpcMaxLatency time.Duration
+
+	// L1EthRpcMaxErrorRate is the fraction of failed requests above which an L1 provider is demoted
+	// behind the others when fallbacks are configured. Zero disables the check.
+	L1EthRpcMaxErrorRate float64
+
+	// L1EthRpcRateLimit is the rate (in requests / second) that each method of the L1 providers is
+	// limited to. The limit is lowered while a provider reports rate limiting. Zero disables it.
+	L1EthRpcRateLimit float64
+
 	// RollupRpc is the HTTP provider URL for the rollup node.
 	RollupRpc string
 
@@ -53,6 +72,9 @@ type Config struct {
 
 	RPCConfig *oprpc.CLIConfig
 
+	// AdminRPCConfig configures the admin API, which is disabled by default.
+	AdminRPCConfig challengerrpc.CLIConfig
+
 	LogConfig *oplog.CLIConfig
 
 	MetricsConfig *opmetrics.CLIConfig
@@ -76,6 +98,15 @@ func (c Config) Check() error {
 	if c.NetworkTimeout == 0 {
 		return ErrInvalidNetworkTimeout
 	}
+	if c.L1EthRpcMaxLatency < 0 {
+		return ErrInvalidMaxLatency
+	}
+	if c.L1EthRpcMaxErrorRate < 0 || c.L1EthRpcMaxErrorRate > 1 {
+		return ErrInvalidMaxErrorRate
+	}
+	if c.L1EthRpcRateLimit < 0 {
+		return ErrInvalidRateLimit
+	}
 	if c.TxMgrConfig == nil {
 		return ErrMissingTxMgrConfig
 	}
@@ -106,6 +137,9 @@ func (c Config) Check() error {
 	if err := c.TxMgrConfig.Check(); err != nil {
 		return err
 	}
+	if err := c.AdminRPCConfig.Check(); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -172,9 +206,14 @@ func NewConfigFromCLI(ctx *cli.Context) (*Config, error) {
 		DGFAddress:  dgfAddress,
 		TxMgrConfig: &txMgrConfig,
 		// Optional Flags
-		RPCConfig:     &rpcConfig,
-		LogConfig:     &logConfig,
-		MetricsConfig: &metricsConfig,
-		PprofConfig:   &pprofConfig,
+		L1EthRpcFallbacks:    ctx.StringSlice(flags.L1EthRpcFallbackFlag.Name),
+		L1EthRpcMaxLatency:   ctx.Duration(flags.L1EthRpcMaxLatencyFlag.Name),
+		L1EthRpcMaxErrorRate: ctx.Float64(flags.L1EthRpcMaxErrorRateFlag.Name),
+		L1EthRpcRateLimit:    ctx.Float64(flags.L1EthRpcRateLimitFlag.Name),
+		RPCConfig:            &rpcConfig,
+		AdminRPCConfig:       challengerrpc.ReadCLIConfig(ctx),
+		LogConfig:            &logConfig,
+		MetricsConfig:        &metricsConfig,
+		PprofConfig:          &pprofConfig,
 	}, nil
 }
@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	challengerrpc "github.com/ethereum-optimism/optimism/op-challenger/rpc"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	oppprof "github.com/ethereum-optimism/optimism/op-service/pprof"
@@ -125,3 +126,36 @@ func TestNetworkTimeoutRequired(t *testing.T) {
 	err := config.Check()
 	require.ErrorIs(t, err, ErrInvalidNetworkTimeout)
 }
+
+func TestAdminRPCRequiresJWTSecret(t *testing.T) {
+	config := validConfig()
+	config.AdminRPCConfig = challengerrpc.CLIConfig{EnableAdmin: true}
+	err := config.Check()
+	require.ErrorIs(t, err, challengerrpc.ErrMissingJWTSecret)
+
+	config.AdminRPCConfig.JWTSecretPath = "jwt.txt"
+	require.NoError(t, config.Check())
+}
+
+func TestDemotionThresholds(t *testing.T) {
+	config := validConfig()
+	config.L1EthRpcMaxLatency = -time.Second
+	require.ErrorIs(t, config.Check(), ErrInvalidMaxLatency)
+
+	config = validConfig()
+	config.L1EthRpcMaxErrorRate = 1.5
+	require.ErrorIs(t, config.Check(), ErrInvalidMaxErrorRate)
+
+	config.L1EthRpcMaxErrorRate = 0.5
+	config.L1EthRpcMaxLatency = time.Second
+	require.NoError(t, config.Check())
+}
+
+func TestRateLimit(t *testing.T) {
+	config := validConfig()
+	config.L1EthRpcRateLimit = -1
+	require.ErrorIs(t, config.Check(), ErrInvalidRateLimit)
+
+	config.L1EthRpcRateLimit = 10
+	require.NoError(t, config.Check())
+}
@@ -2,29 +2,75 @@ package fault
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
 	"sync"
+	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/ethereum-optimism/optimism/op-node/client"
+)
+
+// Decisions logged by the [Agent] for every claim it evaluates.
+const (
+	decisionAttack    = "attack"
+	decisionDefend    = "defend"
+	decisionNone      = "none"
+	decisionDuplicate = "duplicate"
+	decisionExpired   = "expired"
+	decisionError     = "error"
 )
 
+var (
+	// ErrAgentPanicked is returned when the agent panicked while performing actions.
+	ErrAgentPanicked = errors.New("agent panicked")
+
+	// ErrAgentQuarantined is returned when the agent no longer performs actions, because it panicked before.
+	ErrAgentQuarantined = errors.New("agent quarantined")
+)
+
+// AgentMetricer records what an [Agent] does. It is implemented by [metrics.Metricer].
+type AgentMetricer interface {
+	RecordSolverTick(game common.Address, claims int, duration time.Duration)
+	RecordSolverDecision(decision string, reason string)
+	RecordAgentPanic()
+}
+
 type Agent struct {
 	mu        sync.Mutex
+	tick      uint64
 	game      Game
+	addr      common.Address
 	solver    *Solver
 	trace     TraceProvider
 	responder Responder
 	maxDepth  int
 	log       log.Logger
+	metrics   AgentMetricer
+	// now returns the time the clocks of claims are checked at.
+	now func() time.Time
+
+	// quarantined is set once the agent panicked. It is only accessed by TryPerformActions and TryAddClaim.
+	quarantined bool
 }
 
-func NewAgent(game Game, maxDepth int, trace TraceProvider, responder Responder, log log.Logger) Agent {
+// NewAgent creates a new [Agent] for the game at addr. The logger should carry the address of the game,
+// so that the decisions the agent logs can be attributed to it.
+func NewAgent(game Game, addr common.Address, maxDepth int, trace TraceProvider, responder Responder, log log.Logger, m AgentMetricer) Agent {
 	return Agent{
 		game:      game,
+		addr:      addr,
 		solver:    NewSolver(maxDepth, trace),
 		trace:     trace,
 		responder: responder,
 		maxDepth:  maxDepth,
 		log:       log,
+		metrics:   m,
+		now:       time.Now,
 	}
 }
 
@@ -42,30 +88,119 @@ func (a *Agent) AddClaim(claim Claim) error {
 func (a *Agent) PerformActions() {
 	a.mu.Lock()
 	defer a.mu.Unlock()
-	for _, claim := range a.game.Claims() {
-		_ = a.move(claim)
+	a.tick++
+	start := time.Now()
+	ctx, span := client.StartSpan(context.Background(), spanAgentTick)
+	defer span.End()
+	ctx, trace := client.WithCallTrace(ctx)
+	claims := a.game.Claims()
+	span.SetAttributes(gameAttribute(a.addr), attribute.Int64("agent.tick", int64(a.tick)), attribute.Int("game.claims", len(claims)))
+	for _, claim := range claims {
+		_ = a.move(ctx, a.tick, claim)
+	}
+	a.metrics.RecordSolverTick(a.addr, len(claims), time.Since(start))
+	if count, duration := trace.Total(); count > 0 {
+		slowest, _ := trace.Slowest()
+		a.log.Debug("RPC calls made while performing actions", "tick", a.tick, "calls", count, "duration", duration,
+			"slowest_method", slowest.Method, "slowest_duration", slowest.Duration)
 	}
 }
 
-// move determines & executes the next move given a claim pair
-func (a *Agent) move(claim Claim) error {
-	nextMove, err := a.solver.NextMove(claim)
+// TryPerformActions is like PerformActions, but recovers from a panic while performing the actions,
+// e.g. caused by a pathological game. The panic is logged and recorded and returned as an
+// [ErrAgentPanicked] error. The agent is then quarantined, and later calls return [ErrAgentQuarantined]
+// without performing any actions, since the game state may be inconsistent.
+func (a *Agent) TryPerformActions() error {
+	return a.guard(func() error {
+		a.PerformActions()
+		return nil
+	})
+}
+
+// TryAddClaim is like AddClaim, but recovers from a panic while adding the claim
+// and quarantines the agent like TryPerformActions.
+func (a *Agent) TryAddClaim(claim Claim) error {
+	return a.guard(func() error {
+		return a.AddClaim(claim)
+	})
+}
+
+// guard runs fn unless the agent is quarantined, and quarantines the agent if fn panics.
+func (a *Agent) guard(fn func() error) (err error) {
+	if a.quarantined {
+		return ErrAgentQuarantined
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			a.quarantined = true
+			a.metrics.RecordAgentPanic()
+			a.log.Error("Agent panicked, quarantining game", "tick", a.tick, "panic", r, "stack", string(debug.Stack()))
+			err = fmt.Errorf("%w: %v", ErrAgentPanicked, r)
+		}
+	}()
+	return fn()
+}
+
+// remainingTime returns how long is left to counter the claim, and false if the claim has no clock,
+// e.g. in local games, or its parent is not in the game.
+func (a *Agent) remainingTime(claim Claim) (time.Duration, bool) {
+	if claim.Clock == (Clock{}) {
+		return 0, false
+	}
+	if claim.IsRoot() {
+		return RemainingTime(claim, Clock{}, a.now()), true
+	}
+	for _, parent := range a.game.Claims() {
+		if parent.ClaimData == claim.Parent {
+			return RemainingTime(claim, parent.Clock, a.now()), true
+		}
+	}
+	return 0, false
+}
+
+// move determines & executes the next move given a claim pair.
+// Every decision is logged as a "Solver decision" event with the same set of keys,
+// so that decisions can be aggregated from the logs.
+func (a *Agent) move(ctx context.Context, tick uint64, claim Claim) (err error) {
+	ctx, span := client.StartSpan(ctx, spanAgentMove)
+	span.SetAttributes(gameAttribute(a.addr))
+	span.SetAttributes(claimAttributes(claim)...)
+	defer func() { endSpan(span, err) }()
+	log := a.log.New("tick", tick, "claim_index", claim.ContractIndex, "depth", claim.Depth(),
+		"index_at_depth", claim.IndexAtDepth(), "trace_index", claim.TraceIndex(a.maxDepth))
+	decision, err := a.solver.Decide(claim)
+	span.SetAttributes(attribute.String("decision.reason", string(decision.Reason)))
 	if err != nil {
-		a.log.Warn("Failed to execute the next move", "err", err)
+		a.metrics.RecordSolverDecision(decisionError, string(decision.Reason))
+		log.Warn("Solver decision", "decision", decisionError, "reason", decision.Reason, "err", err)
 		return err
 	}
-	if nextMove == nil {
-		a.log.Info("No next move")
+	if decision.Move == nil {
+		a.metrics.RecordSolverDecision(decisionNone, string(decision.Reason))
+		log.Info("Solver decision", "decision", decisionNone, "reason", decision.Reason)
 		return nil
 	}
-	move := *nextMove
-	log := a.log.New("is_defend", move.DefendsParent(), "depth", move.Depth(), "index_at_depth", move.IndexAtDepth(), "value", move.Value,
-		"letter", string(move.Value[31:]), "trace_index", move.Value[30],
-		"parent_letter", string(claim.Value[31:]), "parent_trace_index", claim.Value[30])
+	move := *decision.Move
+	kind := decisionAttack
+	if move.DefendsParent() {
+		kind = decisionDefend
+	}
 	if a.game.IsDuplicate(move) {
-		log.Debug("Duplicate move")
+		kind = decisionDuplicate
+	} else if remaining, ok := a.remainingTime(claim); ok && remaining == 0 {
+		// The contract would reject the counter, since the clock of the countering team ran out.
+		kind = decisionExpired
+	}
+	a.metrics.RecordSolverDecision(kind, string(decision.Reason))
+	span.SetAttributes(attribute.String("decision", kind))
+	log.Info("Solver decision", "decision", kind, "reason", decision.Reason, "response_depth", move.Depth(),
+		"response_index_at_depth", move.IndexAtDepth(), "response_trace_index", move.TraceIndex(a.maxDepth), "response_value", move.Value)
+	if kind == decisionDuplicate || kind == decisionExpired {
 		return nil
 	}
-	log.Info("Performing move")
-	return a.responder.Respond(context.TODO(), move)
+	if err := a.responder.Respond(ctx, move); err != nil {
+		log.Warn("Failed to respond", "err", err)
+		return err
+	}
+	return nil
 }
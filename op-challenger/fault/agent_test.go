@@ -0,0 +1,131 @@
+package fault
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+)
+
+type mockAgentMetrics struct {
+	games     []common.Address
+	ticks     []int
+	decisions map[string]int
+	panics    int
+}
+
+func (m *mockAgentMetrics) RecordSolverTick(game common.Address, claims int, duration time.Duration) {
+	m.games = append(m.games, game)
+	m.ticks = append(m.ticks, claims)
+}
+
+func (m *mockAgentMetrics) RecordSolverDecision(decision string, reason string) {
+	m.decisions[decision+"/"+reason]++
+}
+
+func (m *mockAgentMetrics) RecordAgentPanic() {
+	m.panics++
+}
+
+// panickingTraceProvider panics on every lookup.
+type panickingTraceProvider struct{}
+
+func (panickingTraceProvider) Get(i uint64) (common.Hash, error) {
+	panic("pathological game")
+}
+
+// TestAgent_Metrics tests that the agent records its ticks, labelled with its game, and its decisions.
+func TestAgent_Metrics(t *testing.T) {
+	maxDepth := 3
+	provider := NewAlphabetProvider("abcdefgh", uint64(maxDepth))
+	root := Claim{ClaimData: ClaimData{Value: common.Hash{0xff}, Position: NewPosition(0, 0)}}
+	m := &mockAgentMetrics{decisions: make(map[string]int)}
+	responder := &mockResponder{}
+	game := common.Address{0xfd}
+	agent := NewAgent(NewGameState(root), game, maxDepth, provider, responder, testlog.Logger(t, log.LvlError), m)
+
+	agent.PerformActions()
+	require.Equal(t, []common.Address{game}, m.games)
+	require.Equal(t, []int{1}, m.ticks)
+	require.Equal(t, map[string]int{decisionAttack + "/" + string(ReasonRootDisagreed): 1}, m.decisions)
+	require.Len(t, responder.responses, 1)
+
+	require.NoError(t, agent.AddClaim(responder.responses[0]))
+	agent.PerformActions()
+	require.Equal(t, []int{1, 2}, m.ticks)
+	require.Equal(t, 1, m.decisions[decisionDuplicate+"/"+string(ReasonRootDisagreed)])
+}
+
+// TestAgent_ExpiredClock tests that the agent does not counter claims once its clock ran out,
+// but still does in the final second the contract accepts.
+func TestAgent_ExpiredClock(t *testing.T) {
+	maxDepth := 3
+	provider := NewAlphabetProvider("abcdefgh", uint64(maxDepth))
+	root := Claim{ClaimData: ClaimData{Value: common.Hash{0xff}, Position: NewPosition(0, 0)}, Clock: Clock{Timestamp: 1000}}
+	m := &mockAgentMetrics{decisions: make(map[string]int)}
+	responder := &mockResponder{}
+	agent := NewAgent(NewGameState(root), common.Address{}, maxDepth, provider, responder, testlog.Logger(t, log.LvlError), m)
+
+	agent.now = func() time.Time { return time.Unix(1000, 0).Add(GameDuration/2 + time.Second) }
+	agent.PerformActions()
+	require.Equal(t, map[string]int{decisionExpired + "/" + string(ReasonRootDisagreed): 1}, m.decisions)
+	require.Empty(t, responder.responses)
+
+	agent.now = func() time.Time { return time.Unix(1000, 0).Add(GameDuration / 2) }
+	agent.PerformActions()
+	require.Equal(t, 1, m.decisions[decisionAttack+"/"+string(ReasonRootDisagreed)])
+	require.Len(t, responder.responses, 1)
+}
+
+// TestAgent_TryPerformActions_Panic tests that a panicking agent is recovered and quarantined.
+func TestAgent_TryPerformActions_Panic(t *testing.T) {
+	root := Claim{ClaimData: ClaimData{Value: common.Hash{0xff}, Position: NewPosition(0, 0)}}
+	m := &mockAgentMetrics{decisions: make(map[string]int)}
+	responder := &mockResponder{}
+	agent := NewAgent(NewGameState(root), common.Address{}, 3, panickingTraceProvider{}, responder, testlog.Logger(t, log.LvlCrit), m)
+
+	require.ErrorIs(t, agent.TryPerformActions(), ErrAgentPanicked)
+	require.Equal(t, 1, m.panics)
+	require.ErrorIs(t, agent.TryPerformActions(), ErrAgentQuarantined)
+	require.Equal(t, 1, m.panics)
+	require.Empty(t, responder.responses)
+
+	// The lock is released, so claims can still be added.
+	require.NoError(t, agent.AddClaim(Claim{ClaimData: ClaimData{Value: common.Hash{0x01}, Position: NewPosition(1, 0)}, Parent: root.ClaimData}))
+}
+
+// panickingGame panics when a claim is added.
+type panickingGame struct {
+	Game
+}
+
+func (panickingGame) Put(claim Claim) error {
+	panic("pathological claim")
+}
+
+// TestAgent_TryAddClaim_Panic tests that a panic while adding a claim quarantines the agent
+// and that runAgent keeps draining claims afterwards.
+func TestAgent_TryAddClaim_Panic(t *testing.T) {
+	root := Claim{ClaimData: ClaimData{Value: common.Hash{0xff}, Position: NewPosition(0, 0)}}
+	m := &mockAgentMetrics{decisions: make(map[string]int)}
+	agent := NewAgent(panickingGame{NewGameState(root)}, common.Address{}, 3, NewAlphabetProvider("abcdefgh", 3), &mockResponder{}, testlog.Logger(t, log.LvlCrit), m)
+	claim := Claim{ClaimData: ClaimData{Value: common.Hash{0x01}, Position: NewPosition(1, 0)}, Parent: root.ClaimData}
+
+	claims := make(chan Claim)
+	done := make(chan struct{})
+	go func() {
+		runAgent(&agent, claims)
+		close(done)
+	}()
+	claims <- claim
+	claims <- claim
+	close(claims)
+	<-done
+	require.Equal(t, 1, m.panics)
+	require.ErrorIs(t, agent.TryAddClaim(claim), ErrAgentQuarantined)
+	require.ErrorIs(t, agent.TryPerformActions(), ErrAgentQuarantined)
+}
@@ -0,0 +1,167 @@
+package fault
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// ErrNondeterministicTrace is returned when the trace provider returns a different value
+// for a trace index than it did before.
+var ErrNondeterministicTrace = errors.New("trace provider is not deterministic")
+
+// maxAuditedValues is the number of most recently returned values an [AuditingTraceProvider] remembers.
+const maxAuditedValues = 10_000
+
+// TraceAuditConfig configures background audits of trace providers.
+// A zero interval or sample size disables them.
+type TraceAuditConfig struct {
+	// Interval is how often a sample of the returned values is audited.
+	Interval time.Duration
+	// SampleSize is the number of values re-derived by every audit.
+	SampleSize int
+}
+
+// Enabled returns true if audits should be run.
+func (c TraceAuditConfig) Enabled() bool {
+	return c.Interval > 0 && c.SampleSize > 0
+}
+
+// TraceAuditMetricer records the results of trace audits. It is implemented by [metrics.Metricer].
+type TraceAuditMetricer interface {
+	RecordTraceAudit(checked int, mismatches int)
+}
+
+// AuditingTraceProvider is a [TraceProvider] that remembers the values it returned, so that
+// a sample of them can later be re-derived from the underlying provider and compared.
+// A mismatch means the trace is not deterministic, and moves based on it may lose the game.
+// Only the most recent values are remembered, up to maxAuditedValues.
+type AuditingTraceProvider struct {
+	trace   TraceProvider
+	log     log.Logger
+	metrics TraceAuditMetricer
+
+	mu       sync.Mutex
+	computed map[uint64]common.Hash
+	// indices is a ring buffer of the remembered trace indices, in the order they were first returned.
+	indices []uint64
+	next    int
+	rand    *rand.Rand
+}
+
+// NewAuditingTraceProvider returns an [AuditingTraceProvider] wrapping trace.
+func NewAuditingTraceProvider(trace TraceProvider, log log.Logger, m TraceAuditMetricer) *AuditingTraceProvider {
+	return &AuditingTraceProvider{
+		trace:    trace,
+		log:      log,
+		metrics:  m,
+		computed: make(map[uint64]common.Hash),
+		rand:     rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// Get returns the value of the underlying provider at the trace index. If the provider returned
+// a different value for the index before, [ErrNondeterministicTrace] is returned instead.
+func (p *AuditingTraceProvider) Get(i uint64) (common.Hash, error) {
+	value, err := p.trace.Get(i)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if err := p.check(i, value); err != nil {
+		p.metrics.RecordTraceAudit(1, 1)
+		return common.Hash{}, err
+	}
+	return value, nil
+}
+
+// check records the value at the trace index, or compares it with the recorded value.
+func (p *AuditingTraceProvider) check(i uint64, value common.Hash) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	prev, ok := p.computed[i]
+	if !ok {
+		p.remember(i, value)
+		return nil
+	}
+	if prev != value {
+		p.log.Error("Trace provider is not deterministic", "trace_index", i, "expected", prev, "actual", value)
+		return fmt.Errorf("%w: trace index %d was %v, now %v", ErrNondeterministicTrace, i, prev, value)
+	}
+	return nil
+}
+
+// remember records the value at the trace index, forgetting the oldest value if the limit is reached.
+// The caller must hold the lock.
+func (p *AuditingTraceProvider) remember(i uint64, value common.Hash) {
+	p.computed[i] = value
+	if len(p.indices) < maxAuditedValues {
+		p.indices = append(p.indices, i)
+		return
+	}
+	delete(p.computed, p.indices[p.next])
+	p.indices[p.next] = i
+	p.next = (p.next + 1) % maxAuditedValues
+}
+
+// sample returns up to n random trace indices that values were returned for.
+func (p *AuditingTraceProvider) sample(n int) []uint64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	indices := make([]uint64, len(p.indices))
+	copy(indices, p.indices)
+	p.rand.Shuffle(len(indices), func(i, j int) { indices[i], indices[j] = indices[j], indices[i] })
+	if len(indices) > n {
+		indices = indices[:n]
+	}
+	return indices
+}
+
+// Audit re-derives up to n randomly chosen values returned before and compares them with
+// the values returned at the time. It returns [ErrNondeterministicTrace] if any of them changed.
+// Errors of the underlying provider are logged and skipped, since they say nothing about determinism.
+func (p *AuditingTraceProvider) Audit(n int) error {
+	indices := p.sample(n)
+	checked, mismatches := 0, 0
+	var firstErr error
+	for _, i := range indices {
+		value, err := p.trace.Get(i)
+		if err != nil {
+			p.log.Warn("Failed to re-derive trace value", "trace_index", i, "err", err)
+			continue
+		}
+		checked++
+		if err := p.check(i, value); err != nil {
+			mismatches++
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	p.metrics.RecordTraceAudit(checked, mismatches)
+	if firstErr != nil {
+		return fmt.Errorf("%d of %d audited trace values changed: %w", mismatches, checked, firstErr)
+	}
+	p.log.Debug("Trace audit passed", "checked", checked)
+	return nil
+}
+
+// RunAudits audits a sample of n values every interval until the context is done.
+// Mismatches are logged and recorded by Audit, so the audit keeps running after one is found.
+func (p *AuditingTraceProvider) RunAudits(ctx context.Context, interval time.Duration, n int) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			_ = p.Audit(n)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package fault
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+)
+
+// flakyTraceProvider returns values that change after every call at the flaky index.
+type flakyTraceProvider struct {
+	flaky uint64
+	calls uint64
+}
+
+func (f *flakyTraceProvider) Get(i uint64) (common.Hash, error) {
+	if i != f.flaky {
+		return common.Hash{byte(i)}, nil
+	}
+	f.calls++
+	return common.Hash{byte(i), byte(f.calls)}, nil
+}
+
+type mockAuditMetrics struct {
+	checked, mismatches int
+}
+
+func (m *mockAuditMetrics) RecordTraceAudit(checked int, mismatches int) {
+	m.checked += checked
+	m.mismatches += mismatches
+}
+
+// TestAuditingTraceProvider_Deterministic tests that audits of a deterministic trace pass.
+func TestAuditingTraceProvider_Deterministic(t *testing.T) {
+	m := &mockAuditMetrics{}
+	provider := NewAuditingTraceProvider(NewAlphabetProvider("abcdefgh", 3), testlog.Logger(t, log.LvlError), m)
+	for i := uint64(0); i < 8; i++ {
+		_, err := provider.Get(i)
+		require.NoError(t, err)
+	}
+	require.NoError(t, provider.Audit(5))
+	require.Equal(t, 5, m.checked)
+	require.NoError(t, provider.Audit(100))
+	require.Equal(t, 13, m.checked)
+	require.Zero(t, m.mismatches)
+}
+
+// TestAuditingTraceProvider_Nondeterministic tests that changed values are detected by audits and by Get.
+func TestAuditingTraceProvider_Nondeterministic(t *testing.T) {
+	m := &mockAuditMetrics{}
+	provider := NewAuditingTraceProvider(&flakyTraceProvider{flaky: 2}, testlog.Logger(t, log.LvlCrit), m)
+	for i := uint64(0); i < 4; i++ {
+		_, err := provider.Get(i)
+		require.NoError(t, err)
+	}
+
+	require.ErrorIs(t, provider.Audit(4), ErrNondeterministicTrace)
+	require.Equal(t, 4, m.checked)
+	require.Equal(t, 1, m.mismatches)
+
+	_, err := provider.Get(2)
+	require.ErrorIs(t, err, ErrNondeterministicTrace)
+	require.Equal(t, 2, m.mismatches)
+}
+
+// TestAuditingTraceProvider_BoundedMemory tests that only the most recent values are remembered.
+func TestAuditingTraceProvider_BoundedMemory(t *testing.T) {
+	provider := NewAuditingTraceProvider(&flakyTraceProvider{flaky: math.MaxUint64}, testlog.Logger(t, log.LvlError), &mockAuditMetrics{})
+	for i := uint64(0); i < maxAuditedValues+10; i++ {
+		_, err := provider.Get(i)
+		require.NoError(t, err)
+	}
+	require.Len(t, provider.computed, maxAuditedValues)
+	require.Len(t, provider.indices, maxAuditedValues)
+	require.NotContains(t, provider.computed, uint64(9))
+	require.Contains(t, provider.computed, uint64(10))
+	require.Contains(t, provider.computed, uint64(maxAuditedValues+9))
+}
@@ -0,0 +1,75 @@
+package fault
+
+import (
+	"math/big"
+	"time"
+)
+
+// GameDuration is the duration of a fault dispute game.
+// Each team may use at most half of it across all of its moves.
+const GameDuration = 7 * 24 * time.Hour
+
+// ClockDuration is the time a team has used on its chess clock, in seconds.
+type ClockDuration uint64
+
+// Duration returns the clock duration as a [time.Duration].
+func (d ClockDuration) Duration() time.Duration {
+	return time.Duration(d) * time.Second
+}
+
+// Clock is the chess clock of a claim, as stored by the contract.
+type Clock struct {
+	// Duration is the time the claimant's team had used when the claim was made.
+	Duration ClockDuration
+	// Timestamp is the unix time in seconds the claim was made at.
+	Timestamp uint64
+}
+
+// NewClockFromBigInt unpacks a clock in the contract encoding,
+// which stores the duration in the high and the timestamp in the low 64 bits of a uint128.
+// A nil clock unpacks to the zero clock.
+func NewClockFromBigInt(packed *big.Int) Clock {
+	if packed == nil {
+		return Clock{}
+	}
+	timestamp := new(big.Int).And(packed, new(big.Int).SetUint64(^uint64(0)))
+	duration := new(big.Int).Rsh(packed, 64)
+	return Clock{
+		Duration:  ClockDuration(duration.Uint64()),
+		Timestamp: timestamp.Uint64(),
+	}
+}
+
+// BigInt packs the clock in the contract encoding.
+func (c Clock) BigInt() *big.Int {
+	packed := new(big.Int).Lsh(new(big.Int).SetUint64(uint64(c.Duration)), 64)
+	return packed.Or(packed, new(big.Int).SetUint64(c.Timestamp))
+}
+
+// ResponseClock returns the clock a counter to the claim would get if it was made at now.
+// The counter is made by the team of the claim's parent, so its clock continues from the
+// parent's duration with the time that passed since the claim was made.
+// parentClock is ignored for the root claim.
+func ResponseClock(claim Claim, parentClock Clock, now time.Time) Clock {
+	var duration ClockDuration
+	if !claim.IsRoot() {
+		duration = parentClock.Duration
+	}
+	timestamp := uint64(now.Unix())
+	if timestamp > claim.Clock.Timestamp {
+		duration += ClockDuration(timestamp - claim.Clock.Timestamp)
+	}
+	return Clock{Duration: duration, Timestamp: timestamp}
+}
+
+// RemainingTime returns how long the team countering the claim has left, at now, before
+// its clock exceeds half of the [GameDuration] and the contract rejects the counter.
+// The contract accepts a clock of exactly half of the game duration, so the counter may be
+// made as long as the remaining time is positive, including the final second.
+func RemainingTime(claim Claim, parentClock Clock, now time.Time) time.Duration {
+	used := ResponseClock(claim, parentClock, now).Duration.Duration()
+	if used > GameDuration/2 {
+		return 0
+	}
+	return GameDuration/2 - used + time.Second
+}
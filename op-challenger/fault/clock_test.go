@@ -0,0 +1,45 @@
+package fault
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestClock_BigInt tests that clocks round trip through the contract encoding.
+func TestClock_BigInt(t *testing.T) {
+	clock := Clock{Duration: 3600, Timestamp: 1690000000}
+	packed := clock.BigInt()
+	expected := new(big.Int).Lsh(big.NewInt(3600), 64)
+	expected.Add(expected, big.NewInt(1690000000))
+	require.Equal(t, expected, packed)
+	require.Equal(t, clock, NewClockFromBigInt(packed))
+	require.Equal(t, Clock{}, NewClockFromBigInt(nil))
+}
+
+// TestResponseClock tests that the response clock continues from the parent's duration.
+func TestResponseClock(t *testing.T) {
+	root := Claim{ClaimData: ClaimData{Position: NewPosition(0, 0)}, Clock: Clock{Timestamp: 1000}}
+	attack := Claim{ClaimData: ClaimData{Position: NewPosition(1, 0)}, Clock: Clock{Duration: 100, Timestamp: 1100}}
+
+	// Responses to the root claim start from zero, whatever parent clock is passed.
+	require.Equal(t, Clock{Duration: 50, Timestamp: 1050}, ResponseClock(root, Clock{Duration: 999}, time.Unix(1050, 0)))
+	// Responses to other claims add the time since the claim to the parent's duration.
+	require.Equal(t, Clock{Duration: 10 + 200, Timestamp: 1300}, ResponseClock(attack, Clock{Duration: 10}, time.Unix(1300, 0)))
+}
+
+// TestRemainingTime tests the time left to counter a claim.
+func TestRemainingTime(t *testing.T) {
+	claim := Claim{ClaimData: ClaimData{Position: NewPosition(1, 0)}, Clock: Clock{Timestamp: 1000}}
+	parentClock := Clock{Duration: ClockDuration(time.Hour / time.Second)}
+
+	require.Equal(t, GameDuration/2-time.Hour-time.Minute+time.Second, RemainingTime(claim, parentClock, time.Unix(1060, 0)))
+	require.Equal(t, time.Duration(0), RemainingTime(claim, parentClock, time.Unix(1000, 0).Add(GameDuration)))
+
+	// A clock of exactly half of the game duration is still accepted by the contract.
+	atLimit := time.Unix(1000, 0).Add(GameDuration/2 - time.Hour)
+	require.Equal(t, time.Second, RemainingTime(claim, parentClock, atLimit))
+	require.Equal(t, time.Duration(0), RemainingTime(claim, parentClock, atLimit.Add(time.Second)))
+}
@@ -2,8 +2,10 @@ package examples
 
 import (
 	"os"
+	"time"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/fault"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
 )
@@ -26,6 +28,7 @@ func FullGame() {
 		},
 	}
 
-	o := fault.NewOrchestrator(maxDepth, []fault.TraceProvider{canonicalProvider, disputedProvider}, []string{"charlie", "mallory"}, root)
+	o := fault.NewOrchestrator(maxDepth, []fault.TraceProvider{canonicalProvider, disputedProvider}, []string{"charlie", "mallory"}, root, fault.NewControls(), metrics.NoopMetrics,
+		fault.TraceAuditConfig{Interval: 100 * time.Millisecond, SampleSize: 4})
 	o.Start()
 }
@@ -0,0 +1,165 @@
+package fault
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	// ErrPaused is returned when a response is made while sending transactions is paused.
+	ErrPaused = errors.New("sending transactions is paused")
+
+	// ErrGameIgnored is returned when a response is made in a game that is ignored.
+	ErrGameIgnored = errors.New("game is ignored")
+)
+
+// InFlightMove describes a response that is currently being sent.
+type InFlightMove struct {
+	Game         common.Address `json:"game"`
+	ParentIndex  int            `json:"parentIndex"`
+	Depth        int            `json:"depth"`
+	IndexAtDepth int            `json:"indexAtDepth"`
+	Value        common.Hash    `json:"value"`
+	Started      time.Time      `json:"started"`
+}
+
+// Controls lets operators intervene in the challenger at runtime, by pausing
+// transaction sending, ignoring specific games, and inspecting in-flight moves.
+// It takes effect through the [Responder]s returned by [Controls.Responder].
+type Controls struct {
+	mu       sync.Mutex
+	paused   bool
+	ignored  map[common.Address]struct{}
+	inFlight map[uint64]InFlightMove
+	nextID   uint64
+}
+
+// NewControls returns new [Controls] with sending enabled and no ignored games.
+func NewControls() *Controls {
+	return &Controls{
+		ignored:  make(map[common.Address]struct{}),
+		inFlight: make(map[uint64]InFlightMove),
+	}
+}
+
+// Pause stops any further responses from being sent. In-flight responses are not aborted.
+func (c *Controls) Pause() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.paused = true
+}
+
+// Resume allows responses to be sent again.
+func (c *Controls) Resume() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.paused = false
+}
+
+// Paused returns true if sending responses is paused.
+func (c *Controls) Paused() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.paused
+}
+
+// IgnoreGame stops responses from being sent in the given game.
+func (c *Controls) IgnoreGame(game common.Address) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ignored[game] = struct{}{}
+}
+
+// UnignoreGame allows responses to be sent in the given game again.
+func (c *Controls) UnignoreGame(game common.Address) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.ignored, game)
+}
+
+// IgnoredGames returns the ignored games, sorted by address.
+func (c *Controls) IgnoredGames() []common.Address {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	games := make([]common.Address, 0, len(c.ignored))
+	for game := range c.ignored {
+		games = append(games, game)
+	}
+	sort.Slice(games, func(i, j int) bool {
+		return games[i].Hex() < games[j].Hex()
+	})
+	return games
+}
+
+// InFlight returns the responses that are currently being sent, oldest first.
+func (c *Controls) InFlight() []InFlightMove {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	moves := make([]InFlightMove, 0, len(c.inFlight))
+	for _, move := range c.inFlight {
+		moves = append(moves, move)
+	}
+	sort.Slice(moves, func(i, j int) bool {
+		return moves[i].Started.Before(moves[j].Started)
+	})
+	return moves
+}
+
+// Responder wraps the [Responder] of the given game so that it is subject to the controls.
+func (c *Controls) Responder(game common.Address, r Responder) Responder {
+	return &controlledResponder{
+		controls: c,
+		game:     game,
+		r:        r,
+	}
+}
+
+// start checks that a response can be sent in the game and records it as in flight.
+func (c *Controls) start(game common.Address, response Claim) (uint64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.paused {
+		return 0, ErrPaused
+	}
+	if _, ok := c.ignored[game]; ok {
+		return 0, fmt.Errorf("%w: %v", ErrGameIgnored, game)
+	}
+	id := c.nextID
+	c.nextID++
+	c.inFlight[id] = InFlightMove{
+		Game:         game,
+		ParentIndex:  response.ParentContractIndex,
+		Depth:        response.Depth(),
+		IndexAtDepth: response.IndexAtDepth(),
+		Value:        response.Value,
+		Started:      time.Now(),
+	}
+	return id, nil
+}
+
+func (c *Controls) done(id uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.inFlight, id)
+}
+
+type controlledResponder struct {
+	controls *Controls
+	game     common.Address
+	r        Responder
+}
+
+func (r *controlledResponder) Respond(ctx context.Context, response Claim) error {
+	id, err := r.controls.start(r.game, response)
+	if err != nil {
+		return err
+	}
+	defer r.controls.done(id)
+	return r.r.Respond(ctx, response)
+}
@@ -0,0 +1,101 @@
+package fault
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+)
+
+// mockResponder records the responses it receives.
+type mockResponder struct {
+	responses []Claim
+}
+
+func (r *mockResponder) Respond(ctx context.Context, response Claim) error {
+	r.responses = append(r.responses, response)
+	return nil
+}
+
+// blockingResponder blocks in Respond until released.
+type blockingResponder struct {
+	started chan struct{}
+	release chan struct{}
+}
+
+func (r *blockingResponder) Respond(ctx context.Context, response Claim) error {
+	r.started <- struct{}{}
+	<-r.release
+	return nil
+}
+
+// TestControls_Pause tests that responses are rejected while paused.
+func TestControls_Pause(t *testing.T) {
+	controls := NewControls()
+	responder := controls.Responder(common.Address{0x01}, &mockResponder{})
+
+	controls.Pause()
+	require.True(t, controls.Paused())
+	require.ErrorIs(t, responder.Respond(context.Background(), Claim{}), ErrPaused)
+
+	controls.Resume()
+	require.NoError(t, responder.Respond(context.Background(), Claim{}))
+}
+
+// TestControls_PauseBlocksAgents tests that the responses of the orchestrator's agents are
+// subject to the controls.
+func TestControls_PauseBlocksAgents(t *testing.T) {
+	controls := NewControls()
+	root := Claim{ClaimData: ClaimData{Value: common.Hash{0xff}, Position: NewPosition(0, 0)}}
+	o := NewOrchestrator(3, []TraceProvider{NewAlphabetProvider("abcdefgh", 3)}, []string{"honest"}, root, controls, metrics.NoopMetrics, TraceAuditConfig{})
+
+	controls.Pause()
+	require.NoError(t, o.agents[0].TryPerformActions())
+	require.Empty(t, o.responses)
+
+	controls.Resume()
+	require.NoError(t, o.agents[0].TryPerformActions())
+	require.Len(t, o.responses, 1)
+}
+
+// TestControls_IgnoreGame tests that responses are rejected in ignored games only.
+func TestControls_IgnoreGame(t *testing.T) {
+	controls := NewControls()
+	ignored := controls.Responder(common.Address{0x01}, &mockResponder{})
+	other := controls.Responder(common.Address{0x02}, &mockResponder{})
+
+	controls.IgnoreGame(common.Address{0x01})
+	require.Equal(t, []common.Address{{0x01}}, controls.IgnoredGames())
+	require.ErrorIs(t, ignored.Respond(context.Background(), Claim{}), ErrGameIgnored)
+	require.NoError(t, other.Respond(context.Background(), Claim{}))
+
+	controls.UnignoreGame(common.Address{0x01})
+	require.Empty(t, controls.IgnoredGames())
+	require.NoError(t, ignored.Respond(context.Background(), Claim{}))
+}
+
+// TestControls_InFlight tests that responses are reported while being sent.
+func TestControls_InFlight(t *testing.T) {
+	controls := NewControls()
+	inner := &blockingResponder{started: make(chan struct{}), release: make(chan struct{})}
+	responder := controls.Responder(common.Address{0x01}, inner)
+
+	response := Claim{ClaimData: ClaimData{Value: common.Hash{0xaa}, Position: NewPosition(1, 0)}, ParentContractIndex: 3}
+	errCh := make(chan error)
+	go func() {
+		errCh <- responder.Respond(context.Background(), response)
+	}()
+	<-inner.started
+	inFlight := controls.InFlight()
+	require.Len(t, inFlight, 1)
+	require.Equal(t, common.Address{0x01}, inFlight[0].Game)
+	require.Equal(t, 3, inFlight[0].ParentIndex)
+	require.Equal(t, common.Hash{0xaa}, inFlight[0].Value)
+
+	close(inner.release)
+	require.NoError(t, <-errCh)
+	require.Empty(t, controls.InFlight())
+}
@@ -0,0 +1,53 @@
+package fault
+
+// GameDiff describes how the claims of a game differ between two observations,
+// e.g. two monitoring cycles or two RPC providers.
+type GameDiff struct {
+	// Added contains the claims only present in the second observation.
+	Added []Claim
+	// Removed contains the claims only present in the first observation.
+	Removed []Claim
+	// Countered contains the claims that were countered in the second observation only.
+	Countered []Claim
+	// Uncountered contains the claims that were countered in the first observation only.
+	Uncountered []Claim
+}
+
+// Empty returns true if the observations did not differ.
+func (d GameDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Countered) == 0 && len(d.Uncountered) == 0
+}
+
+// DiffGames diffs the claims of two observations of the same game.
+func DiffGames(before, after Game) GameDiff {
+	return DiffClaims(before.Claims(), after.Claims())
+}
+
+// DiffClaims diffs two observations of the claims of the same game.
+// Claims are matched by their [ClaimData], and reported in the order of the observation they come from.
+func DiffClaims(before, after []Claim) GameDiff {
+	var diff GameDiff
+	old := make(map[ClaimData]Claim, len(before))
+	for _, claim := range before {
+		old[claim.ClaimData] = claim
+	}
+	current := make(map[ClaimData]struct{}, len(after))
+	for _, claim := range after {
+		current[claim.ClaimData] = struct{}{}
+		prev, ok := old[claim.ClaimData]
+		switch {
+		case !ok:
+			diff.Added = append(diff.Added, claim)
+		case claim.Countered && !prev.Countered:
+			diff.Countered = append(diff.Countered, claim)
+		case !claim.Countered && prev.Countered:
+			diff.Uncountered = append(diff.Uncountered, claim)
+		}
+	}
+	for _, claim := range before {
+		if _, ok := current[claim.ClaimData]; !ok {
+			diff.Removed = append(diff.Removed, claim)
+		}
+	}
+	return diff
+}
@@ -0,0 +1,42 @@
+package fault
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+// TestDiffClaims tests that added, removed and (un)countered claims are reported.
+func TestDiffClaims(t *testing.T) {
+	root := Claim{ClaimData: ClaimData{Value: common.Hash{0x01}, Position: NewPosition(0, 0)}}
+	attack := Claim{ClaimData: ClaimData{Value: common.Hash{0x02}, Position: NewPosition(1, 0)}, Parent: root.ClaimData}
+	reorged := Claim{ClaimData: ClaimData{Value: common.Hash{0x03}, Position: NewPosition(2, 0)}, Parent: attack.ClaimData}
+	replacement := Claim{ClaimData: ClaimData{Value: common.Hash{0x04}, Position: NewPosition(2, 0)}, Parent: attack.ClaimData}
+
+	counteredRoot := root
+	counteredRoot.Countered = true
+	counteredAttack := attack
+	counteredAttack.Countered = true
+
+	diff := DiffClaims([]Claim{root, counteredAttack, reorged}, []Claim{counteredRoot, attack, replacement})
+	require.Equal(t, GameDiff{
+		Added:       []Claim{replacement},
+		Removed:     []Claim{reorged},
+		Countered:   []Claim{counteredRoot},
+		Uncountered: []Claim{attack},
+	}, diff)
+	require.False(t, diff.Empty())
+}
+
+// TestDiffGames_Same tests that identical games have an empty diff.
+func TestDiffGames_Same(t *testing.T) {
+	root := Claim{ClaimData: ClaimData{Value: common.Hash{0x01}, Position: NewPosition(0, 0)}}
+	attack := Claim{ClaimData: ClaimData{Value: common.Hash{0x02}, Position: NewPosition(1, 0)}, Parent: root.ClaimData}
+	before := NewGameState(root)
+	require.NoError(t, before.Put(attack))
+	after := NewGameState(root)
+	require.NoError(t, after.Put(attack))
+
+	require.True(t, DiffGames(before, after).Empty())
+}
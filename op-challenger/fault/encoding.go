@@ -0,0 +1,389 @@
+package fault
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// EncodingVersion is the version of the JSON and binary game encodings.
+// It must be bumped whenever either encoding changes incompatibly.
+const EncodingVersion = 1
+
+var (
+	ErrUnsupportedVersion = errors.New("unsupported encoding version")
+	ErrNoRootClaim        = errors.New("encoded game has no root claim")
+	ErrInvalidEncoding    = errors.New("invalid binary encoding")
+	ErrInconsistentClaim  = errors.New("encoded claim is inconsistent with its game")
+	ErrBranchingTooLarge  = errors.New("branching factor does not fit the binary encoding")
+)
+
+type positionJSON struct {
+	Depth        int `json:"depth"`
+	IndexAtDepth int `json:"indexAtDepth"`
+	Branching    int `json:"branching,omitempty"`
+}
+
+type claimDataJSON struct {
+	Value    common.Hash  `json:"value"`
+	Position positionJSON `json:"position"`
+}
+
+type clockJSON struct {
+	Duration  uint64 `json:"duration"`
+	Timestamp uint64 `json:"timestamp"`
+}
+
+type provenanceJSON struct {
+	L1Block     uint64         `json:"l1Block"`
+	L1BlockHash common.Hash    `json:"l1BlockHash"`
+	TxHash      common.Hash    `json:"txHash"`
+	Claimant    common.Address `json:"claimant"`
+}
+
+type claimJSON struct {
+	claimDataJSON
+	Countered           bool            `json:"countered"`
+	Clock               clockJSON       `json:"clock"`
+	Parent              *claimDataJSON  `json:"parent,omitempty"`
+	ContractIndex       int             `json:"contractIndex"`
+	ParentContractIndex int             `json:"parentContractIndex"`
+	Provenance          *provenanceJSON `json:"provenance,omitempty"`
+}
+
+type gameJSON struct {
+	Version int     `json:"version"`
+	Claims  []Claim `json:"claims"`
+}
+
+func toClaimDataJSON(c ClaimData) claimDataJSON {
+	return claimDataJSON{
+		Value: c.Value,
+		Position: positionJSON{
+			Depth:        c.depth,
+			IndexAtDepth: c.indexAtDepth,
+			Branching:    c.branching,
+		},
+	}
+}
+
+func (c claimDataJSON) claimData() (ClaimData, error) {
+	position, err := newPosition(c.Position.Depth, c.Position.IndexAtDepth, branchingOrBinary(c.Position.Branching))
+	if err != nil {
+		return ClaimData{}, err
+	}
+	return ClaimData{Value: c.Value, Position: position}, nil
+}
+
+// MarshalJSON implements [json.Marshaler].
+func (c ClaimData) MarshalJSON() ([]byte, error) {
+	return json.Marshal(toClaimDataJSON(c))
+}
+
+// UnmarshalJSON implements [json.Unmarshaler].
+func (c *ClaimData) UnmarshalJSON(data []byte) error {
+	var dec claimDataJSON
+	if err := json.Unmarshal(data, &dec); err != nil {
+		return err
+	}
+	claimData, err := dec.claimData()
+	if err != nil {
+		return err
+	}
+	*c = claimData
+	return nil
+}
+
+// MarshalJSON implements [json.Marshaler].
+// The parent is omitted for the root claim.
+func (c Claim) MarshalJSON() ([]byte, error) {
+	enc := claimJSON{
+		claimDataJSON:       toClaimDataJSON(c.ClaimData),
+		Countered:           c.Countered,
+		Clock:               clockJSON{Duration: uint64(c.Clock.Duration), Timestamp: c.Clock.Timestamp},
+		ContractIndex:       c.ContractIndex,
+		ParentContractIndex: c.ParentContractIndex,
+	}
+	if !c.IsRoot() {
+		parent := toClaimDataJSON(c.Parent)
+		enc.Parent = &parent
+	}
+	if c.Provenance != (Provenance{}) {
+		enc.Provenance = &provenanceJSON{
+			L1Block:     c.Provenance.L1Block,
+			L1BlockHash: c.Provenance.L1BlockHash,
+			TxHash:      c.Provenance.TxHash,
+			Claimant:    c.Provenance.Claimant,
+		}
+	}
+	return json.Marshal(enc)
+}
+
+// UnmarshalJSON implements [json.Unmarshaler].
+func (c *Claim) UnmarshalJSON(data []byte) error {
+	var dec claimJSON
+	if err := json.Unmarshal(data, &dec); err != nil {
+		return err
+	}
+	claimData, err := dec.claimDataJSON.claimData()
+	if err != nil {
+		return err
+	}
+	*c = Claim{
+		ClaimData:           claimData,
+		Countered:           dec.Countered,
+		Clock:               Clock{Duration: ClockDuration(dec.Clock.Duration), Timestamp: dec.Clock.Timestamp},
+		ContractIndex:       dec.ContractIndex,
+		ParentContractIndex: dec.ParentContractIndex,
+	}
+	if dec.Parent != nil {
+		if c.IsRoot() {
+			return fmt.Errorf("%w: root claim has a parent", ErrInconsistentClaim)
+		}
+		if c.Parent, err = dec.Parent.claimData(); err != nil {
+			return fmt.Errorf("invalid parent: %w", err)
+		}
+	}
+	if dec.Provenance != nil {
+		c.Provenance = Provenance{
+			L1Block:     dec.Provenance.L1Block,
+			L1BlockHash: dec.Provenance.L1BlockHash,
+			TxHash:      dec.Provenance.TxHash,
+			Claimant:    dec.Provenance.Claimant,
+		}
+	}
+	return nil
+}
+
+// sortedClaims returns the claims of the game ordered by depth, then index at depth and value,
+// so that parents come before their children and the encoding of a game is deterministic.
+func sortedClaims(game Game) []Claim {
+	claims := game.Claims()
+	sort.Slice(claims, func(i, j int) bool {
+		a, b := claims[i], claims[j]
+		if a.Depth() != b.Depth() {
+			return a.Depth() < b.Depth()
+		}
+		if a.IndexAtDepth() != b.IndexAtDepth() {
+			return a.IndexAtDepth() < b.IndexAtDepth()
+		}
+		return a.Value.Big().Cmp(b.Value.Big()) < 0
+	})
+	return claims
+}
+
+// gameFromClaims rebuilds a game from claims ordered so that parents come first.
+// The claims come from untrusted input, so every claim must share the branching factor
+// of the root claim and sit one level below its parent.
+func gameFromClaims(claims []Claim) (Game, error) {
+	if len(claims) == 0 || !claims[0].IsRoot() {
+		return nil, ErrNoRootClaim
+	}
+	branching := claims[0].Branching()
+	game := NewGameState(claims[0])
+	for _, claim := range claims[1:] {
+		if claim.Branching() != branching || claim.Parent.Branching() != branching {
+			return nil, fmt.Errorf("%w: claim at depth %d index %d has branching %d, parent %d, game %d",
+				ErrInconsistentClaim, claim.Depth(), claim.IndexAtDepth(), claim.Branching(), claim.Parent.Branching(), branching)
+		}
+		if claim.Parent.Depth() != claim.Depth()-1 {
+			return nil, fmt.Errorf("%w: claim at depth %d has parent at depth %d",
+				ErrInconsistentClaim, claim.Depth(), claim.Parent.Depth())
+		}
+		if err := game.Put(claim); err != nil {
+			return nil, fmt.Errorf("failed to add claim at depth %d index %d: %w", claim.Depth(), claim.IndexAtDepth(), err)
+		}
+	}
+	return game, nil
+}
+
+// EncodeGameJSON encodes the claims of the game as versioned JSON.
+func EncodeGameJSON(game Game) ([]byte, error) {
+	return json.Marshal(gameJSON{Version: EncodingVersion, Claims: sortedClaims(game)})
+}
+
+// DecodeGameJSON decodes a game encoded with [EncodeGameJSON].
+func DecodeGameJSON(data []byte) (Game, error) {
+	var dec gameJSON
+	if err := json.Unmarshal(data, &dec); err != nil {
+		return nil, err
+	}
+	if dec.Version != EncodingVersion {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedVersion, dec.Version)
+	}
+	return gameFromClaims(dec.Claims)
+}
+
+// claimDataBinaryLen is the length of an encoded [ClaimData]: the value, the generalized index
+// and the branching factor, which is a single byte and so at most [math.MaxUint8].
+const claimDataBinaryLen = common.HashLength + 8 + 1
+
+// claimBinaryLen is the length of an encoded [Claim]: the claim data, the countered flag,
+// the clock, the parent claim data, both contract indices and the provenance.
+const claimBinaryLen = claimDataBinaryLen + 1 + 8 + 8 + claimDataBinaryLen + 4 + 4 + 8 + 2*common.HashLength + common.AddressLength
+
+func appendClaimData(out []byte, c ClaimData) ([]byte, error) {
+	if c.branching > math.MaxUint8 {
+		return nil, fmt.Errorf("%w: %d", ErrBranchingTooLarge, c.branching)
+	}
+	out = append(out, c.Value.Bytes()...)
+	out = binary.BigEndian.AppendUint64(out, c.ToGIndex())
+	return append(out, byte(c.branching)), nil
+}
+
+func readClaimData(data []byte) (ClaimData, error) {
+	position, err := positionFromGIndex(binary.BigEndian.Uint64(data[common.HashLength:]), branchingOrBinary(int(data[common.HashLength+8])))
+	if err != nil {
+		return ClaimData{}, fmt.Errorf("%w: %v", ErrInvalidEncoding, err)
+	}
+	return ClaimData{Value: common.BytesToHash(data[:common.HashLength]), Position: position}, nil
+}
+
+func branchingOrBinary(branching int) int {
+	if branching == 0 {
+		return BinaryBranching
+	}
+	return branching
+}
+
+// EncodeGameBinary encodes the claims of the game in a compact, versioned binary format.
+// The encoding starts with the version byte and the claim count, followed by fixed size claims.
+// Games with a branching factor above [math.MaxUint8] cannot be encoded and return [ErrBranchingTooLarge].
+func EncodeGameBinary(game Game) ([]byte, error) {
+	claims := sortedClaims(game)
+	out := make([]byte, 0, 1+4+len(claims)*claimBinaryLen)
+	out = append(out, EncodingVersion)
+	out = binary.BigEndian.AppendUint32(out, uint32(len(claims)))
+	var err error
+	for _, claim := range claims {
+		if out, err = appendClaimData(out, claim.ClaimData); err != nil {
+			return nil, err
+		}
+		countered := byte(0)
+		if claim.Countered {
+			countered = 1
+		}
+		out = append(out, countered)
+		out = binary.BigEndian.AppendUint64(out, uint64(claim.Clock.Duration))
+		out = binary.BigEndian.AppendUint64(out, claim.Clock.Timestamp)
+		if out, err = appendClaimData(out, claim.Parent); err != nil {
+			return nil, err
+		}
+		out = binary.BigEndian.AppendUint32(out, uint32(claim.ContractIndex))
+		out = binary.BigEndian.AppendUint32(out, uint32(claim.ParentContractIndex))
+		out = binary.BigEndian.AppendUint64(out, claim.Provenance.L1Block)
+		out = append(out, claim.Provenance.L1BlockHash.Bytes()...)
+		out = append(out, claim.Provenance.TxHash.Bytes()...)
+		out = append(out, claim.Provenance.Claimant.Bytes()...)
+	}
+	return out, nil
+}
+
+// DecodeGameBinary decodes a game encoded with [EncodeGameBinary].
+func DecodeGameBinary(data []byte) (Game, error) {
+	if len(data) < 5 {
+		return nil, ErrInvalidEncoding
+	}
+	if data[0] != EncodingVersion {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedVersion, data[0])
+	}
+	count := int(binary.BigEndian.Uint32(data[1:5]))
+	data = data[5:]
+	if len(data) != count*claimBinaryLen {
+		return nil, fmt.Errorf("%w: expected %d bytes for %d claims, got %d", ErrInvalidEncoding, count*claimBinaryLen, count, len(data))
+	}
+	claims := make([]Claim, 0, count)
+	for ; len(data) > 0; data = data[claimBinaryLen:] {
+		claimData, err := readClaimData(data)
+		if err != nil {
+			return nil, err
+		}
+		claim := Claim{ClaimData: claimData}
+		rest := data[claimDataBinaryLen:]
+		claim.Countered = rest[0] == 1
+		claim.Clock.Duration = ClockDuration(binary.BigEndian.Uint64(rest[1:]))
+		claim.Clock.Timestamp = binary.BigEndian.Uint64(rest[9:])
+		rest = rest[17:]
+		if !claim.IsRoot() {
+			if claim.Parent, err = readClaimData(rest); err != nil {
+				return nil, err
+			}
+		}
+		rest = rest[claimDataBinaryLen:]
+		claim.ContractIndex = int(binary.BigEndian.Uint32(rest))
+		claim.ParentContractIndex = int(binary.BigEndian.Uint32(rest[4:]))
+		claim.Provenance.L1Block = binary.BigEndian.Uint64(rest[8:])
+		rest = rest[16:]
+		claim.Provenance.L1BlockHash = common.BytesToHash(rest[:common.HashLength])
+		claim.Provenance.TxHash = common.BytesToHash(rest[common.HashLength : 2*common.HashLength])
+		claim.Provenance.Claimant = common.BytesToAddress(rest[2*common.HashLength : 2*common.HashLength+common.AddressLength])
+		claims = append(claims, claim)
+	}
+	return gameFromClaims(claims)
+}
+
+type replayActionJSON struct {
+	Claim     Claim    `json:"claim"`
+	Decision  Decision `json:"decision"`
+	Duplicate bool     `json:"duplicate"`
+	Error     string   `json:"error,omitempty"`
+}
+
+type replayActionsJSON struct {
+	Version int            `json:"version"`
+	Actions []ReplayAction `json:"actions"`
+}
+
+// MarshalJSON implements [json.Marshaler].
+// The error is encoded as its message.
+func (a ReplayAction) MarshalJSON() ([]byte, error) {
+	enc := replayActionJSON{
+		Claim:     a.Claim,
+		Decision:  a.Decision,
+		Duplicate: a.Duplicate,
+	}
+	if a.Err != nil {
+		enc.Error = a.Err.Error()
+	}
+	return json.Marshal(enc)
+}
+
+// UnmarshalJSON implements [json.Unmarshaler].
+// A decoded error only keeps the message, so it does not match the original error with [errors.Is].
+func (a *ReplayAction) UnmarshalJSON(data []byte) error {
+	var dec replayActionJSON
+	if err := json.Unmarshal(data, &dec); err != nil {
+		return err
+	}
+	*a = ReplayAction{
+		Claim:     dec.Claim,
+		Decision:  dec.Decision,
+		Duplicate: dec.Duplicate,
+	}
+	if dec.Error != "" {
+		a.Err = errors.New(dec.Error)
+	}
+	return nil
+}
+
+// EncodeActionsJSON encodes the actions, e.g. as returned by [Replay], as versioned JSON.
+func EncodeActionsJSON(actions []ReplayAction) ([]byte, error) {
+	return json.Marshal(replayActionsJSON{Version: EncodingVersion, Actions: actions})
+}
+
+// DecodeActionsJSON decodes actions encoded with [EncodeActionsJSON].
+func DecodeActionsJSON(data []byte) ([]ReplayAction, error) {
+	var dec replayActionsJSON
+	if err := json.Unmarshal(data, &dec); err != nil {
+		return nil, err
+	}
+	if dec.Version != EncodingVersion {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedVersion, dec.Version)
+	}
+	return dec.Actions, nil
+}
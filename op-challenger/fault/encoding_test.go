@@ -0,0 +1,197 @@
+package fault
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func createEncodingTestGame(t *testing.T) Game {
+	root := Claim{ClaimData: ClaimData{Value: common.Hash{0x01}, Position: NewPosition(0, 0)}, Clock: Clock{Timestamp: 1000}}
+	attack := Claim{
+		ClaimData:     ClaimData{Value: common.Hash{0x02}, Position: NewPosition(1, 0)},
+		Countered:     true,
+		Clock:         Clock{Duration: 10, Timestamp: 1010},
+		Parent:        root.ClaimData,
+		ContractIndex: 1,
+	}
+	defend := Claim{
+		ClaimData:           ClaimData{Value: common.Hash{0x03}, Position: NewPosition(2, 2)},
+		Clock:               Clock{Duration: 20, Timestamp: 1030},
+		Parent:              attack.ClaimData,
+		ContractIndex:       2,
+		ParentContractIndex: 1,
+		Provenance:          Provenance{L1Block: 12, L1BlockHash: common.Hash{0xbb}, TxHash: common.Hash{0xdd}, Claimant: common.Address{0xee}},
+	}
+	game := NewGameState(root)
+	require.NoError(t, game.Put(attack))
+	require.NoError(t, game.Put(defend))
+	return game
+}
+
+// TestEncodeGameJSON tests that games round trip through the JSON encoding.
+func TestEncodeGameJSON(t *testing.T) {
+	game := createEncodingTestGame(t)
+	data, err := EncodeGameJSON(game)
+	require.NoError(t, err)
+
+	decoded, err := DecodeGameJSON(data)
+	require.NoError(t, err)
+	require.ElementsMatch(t, game.Claims(), decoded.Claims())
+
+	// The encoding is deterministic.
+	again, err := EncodeGameJSON(decoded)
+	require.NoError(t, err)
+	require.Equal(t, data, again)
+}
+
+// TestEncodeGameJSON_UnsupportedVersion tests that other encoding versions are rejected.
+func TestEncodeGameJSON_UnsupportedVersion(t *testing.T) {
+	_, err := DecodeGameJSON([]byte(`{"version":2,"claims":[]}`))
+	require.ErrorIs(t, err, ErrUnsupportedVersion)
+	_, err = DecodeGameJSON([]byte(`{"version":1,"claims":[]}`))
+	require.ErrorIs(t, err, ErrNoRootClaim)
+}
+
+// TestDecodeGameJSON_Invalid tests that positions and claims inconsistent with the game are rejected.
+func TestDecodeGameJSON_Invalid(t *testing.T) {
+	one, two := `"`+common.Hash{0x01}.Hex()+`"`, `"`+common.Hash{0x02}.Hex()+`"`
+	root := `{"value":` + one + `,"position":{"depth":0,"indexAtDepth":0},"countered":true,"clock":{"duration":0,"timestamp":0},"contractIndex":0,"parentContractIndex":0}`
+	claim := func(position string, parent string) string {
+		return `{"value":` + two + `,"position":` + position + `,"countered":false,"clock":{"duration":0,"timestamp":0},` +
+			`"parent":{"value":` + one + `,"position":` + parent + `},"contractIndex":1,"parentContractIndex":0}`
+	}
+	rootPosition := `{"depth":0,"indexAtDepth":0}`
+	tests := []struct {
+		name   string
+		claims string
+		err    error
+	}{
+		{"BranchingOne", `{"value":` + one + `,"position":{"depth":0,"indexAtDepth":0,"branching":1}}`, ErrInvalidPosition},
+		{"NegativeDepth", `{"value":` + one + `,"position":{"depth":-1,"indexAtDepth":0}}`, ErrInvalidPosition},
+		{"IndexOutOfRange", root + "," + claim(`{"depth":1,"indexAtDepth":2}`, rootPosition), ErrInvalidPosition},
+		{"TooDeep", root + "," + claim(`{"depth":64,"indexAtDepth":0}`, rootPosition), ErrInvalidPosition},
+		{"RootWithParent", claim(rootPosition, rootPosition), ErrInconsistentClaim},
+		{"MixedBranching", root + "," + claim(`{"depth":1,"indexAtDepth":0,"branching":4}`, `{"depth":0,"indexAtDepth":0,"branching":4}`), ErrInconsistentClaim},
+		{"ParentNotAbove", root + "," + claim(`{"depth":2,"indexAtDepth":0}`, rootPosition), ErrInconsistentClaim},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			_, err := DecodeGameJSON([]byte(`{"version":1,"claims":[` + test.claims + `]}`))
+			require.ErrorIs(t, err, test.err)
+		})
+	}
+}
+
+// TestClaim_JSON tests the JSON encoding of a single claim.
+func TestClaim_JSON(t *testing.T) {
+	claim := Claim{
+		ClaimData:     ClaimData{Value: common.Hash{0x02}, Position: NewPosition(1, 0)},
+		Parent:        ClaimData{Value: common.Hash{0x01}, Position: NewPosition(0, 0)},
+		ContractIndex: 1,
+	}
+	data, err := json.Marshal(claim)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"value": "0x0200000000000000000000000000000000000000000000000000000000000000",
+		"position": {"depth": 1, "indexAtDepth": 0},
+		"countered": false,
+		"clock": {"duration": 0, "timestamp": 0},
+		"parent": {"value": "0x0100000000000000000000000000000000000000000000000000000000000000", "position": {"depth": 0, "indexAtDepth": 0}},
+		"contractIndex": 1,
+		"parentContractIndex": 0
+	}`, string(data))
+
+	var decoded Claim
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	require.Equal(t, claim, decoded)
+}
+
+// TestEncodeGameBinary tests that games round trip through the binary encoding.
+func TestEncodeGameBinary(t *testing.T) {
+	game := createEncodingTestGame(t)
+	data, err := EncodeGameBinary(game)
+	require.NoError(t, err)
+	require.Len(t, data, 5+3*claimBinaryLen)
+
+	decoded, err := DecodeGameBinary(data)
+	require.NoError(t, err)
+	require.ElementsMatch(t, game.Claims(), decoded.Claims())
+	again, err := EncodeGameBinary(decoded)
+	require.NoError(t, err)
+	require.Equal(t, data, again)
+}
+
+// TestEncodeGameBinary_BranchingTooLarge tests that branching factors that do not fit a byte are rejected.
+func TestEncodeGameBinary_BranchingTooLarge(t *testing.T) {
+	root := Claim{ClaimData: ClaimData{Value: common.Hash{0x01}, Position: NewPositionWithBranching(0, 0, 256)}}
+	_, err := EncodeGameBinary(NewGameState(root))
+	require.ErrorIs(t, err, ErrBranchingTooLarge)
+
+	root.Position = NewPositionWithBranching(0, 0, 255)
+	data, err := EncodeGameBinary(NewGameState(root))
+	require.NoError(t, err)
+	decoded, err := DecodeGameBinary(data)
+	require.NoError(t, err)
+	require.Equal(t, []Claim{root}, decoded.Claims())
+}
+
+// TestDecodeGameBinary_Invalid tests that malformed binary encodings are rejected.
+func TestDecodeGameBinary_Invalid(t *testing.T) {
+	data, err := EncodeGameBinary(createEncodingTestGame(t))
+	require.NoError(t, err)
+
+	_, err = DecodeGameBinary(data[:3])
+	require.ErrorIs(t, err, ErrInvalidEncoding)
+	_, err = DecodeGameBinary(data[:len(data)-1])
+	require.ErrorIs(t, err, ErrInvalidEncoding)
+
+	// The branching factor and generalized index of the root claim follow its value.
+	branching := 5 + common.HashLength + 8
+	invalid := append([]byte(nil), data...)
+	invalid[branching] = 1
+	_, err = DecodeGameBinary(invalid)
+	require.ErrorIs(t, err, ErrInvalidEncoding)
+	invalid = append([]byte(nil), data...)
+	copy(invalid[5+common.HashLength:branching], make([]byte, 8))
+	_, err = DecodeGameBinary(invalid)
+	require.ErrorIs(t, err, ErrInvalidEncoding)
+	invalid = append([]byte(nil), data...)
+	invalid[branching] = 4
+	_, err = DecodeGameBinary(invalid)
+	require.ErrorIs(t, err, ErrInconsistentClaim)
+
+	data[0] = EncodingVersion + 1
+	_, err = DecodeGameBinary(data)
+	require.ErrorIs(t, err, ErrUnsupportedVersion)
+}
+
+// TestEncodeActionsJSON tests that replayed actions round trip through the JSON encoding.
+func TestEncodeActionsJSON(t *testing.T) {
+	claims := createEncodingTestGame(t).Claims()
+	move := Claim{ClaimData: ClaimData{Value: common.Hash{0x04}, Position: NewPosition(2, 0)}, Parent: claims[1].ClaimData, ParentContractIndex: 1}
+	actions := []ReplayAction{
+		{Claim: claims[0], Decision: Decision{Reason: ReasonRootAgreed}},
+		{Claim: claims[1], Decision: Decision{Move: &move, Reason: ReasonClaimDisagreed}, Duplicate: true},
+		{Claim: claims[2], Decision: Decision{Reason: ReasonMaxDepth}, Err: ErrGameDepthReached},
+	}
+	data, err := EncodeActionsJSON(actions)
+	require.NoError(t, err)
+
+	decoded, err := DecodeActionsJSON(data)
+	require.NoError(t, err)
+	require.Len(t, decoded, len(actions))
+	for i, action := range actions {
+		require.Equal(t, action.Claim, decoded[i].Claim)
+		require.Equal(t, action.Decision, decoded[i].Decision)
+		require.Equal(t, action.Duplicate, decoded[i].Duplicate)
+	}
+	require.Nil(t, decoded[0].Err)
+	require.EqualError(t, decoded[2].Err, ErrGameDepthReached.Error())
+
+	_, err = DecodeActionsJSON([]byte(`{"version":2,"actions":[]}`))
+	require.ErrorIs(t, err, ErrUnsupportedVersion)
+}
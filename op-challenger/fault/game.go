@@ -21,6 +21,22 @@ type Game interface {
 	Claims() []Claim
 
 	IsDuplicate(claim Claim) bool
+
+	// ClaimAtPosition returns the first claim added at the position,
+	// and false if there is no claim at the position.
+	ClaimAtPosition(pos Position) (Claim, bool)
+
+	// ClaimsAtDepth returns the claims at the depth, in the order they were added.
+	ClaimsAtDepth(depth int) []Claim
+
+	// AncestorWithTraceIndex returns the closest ancestor of the claim, including the claim itself,
+	// that commits to the given trace index in a game of maxDepth, and false if there is none.
+	AncestorWithTraceIndex(claim Claim, traceIndex uint64, maxDepth int) (Claim, bool)
+
+	// WithClaims returns a copy of the game with the claims applied, leaving the game unchanged.
+	// Claims that are already in the game replace the existing claim, e.g. to mark it countered.
+	// New claims must come after their parents.
+	WithClaims(claims []Claim) (Game, error)
 }
 
 // Node is a node in the game state tree.
@@ -34,20 +50,37 @@ type Node struct {
 type gameState struct {
 	root   Node
 	claims map[ClaimData]Claim
+
+	// Indices over the claims, kept up to date by Put so lookups do not need to walk the tree.
+	nodes      map[ClaimData]*Node
+	byPosition map[Position][]ClaimData
+	byDepth    map[int][]ClaimData
 }
 
 // NewGameState returns a new game state.
 // The provided [Claim] is used as the root node.
 func NewGameState(root Claim) *gameState {
-	claims := make(map[ClaimData]Claim)
-	claims[root.ClaimData] = root
-	return &gameState{
+	g := &gameState{
 		root: Node{
 			self:     root,
 			children: make([]*Node, 0),
 		},
-		claims: claims,
+		claims:     make(map[ClaimData]Claim),
+		nodes:      make(map[ClaimData]*Node),
+		byPosition: make(map[Position][]ClaimData),
+		byDepth:    make(map[int][]ClaimData),
 	}
+	g.index(&g.root)
+	return g
+}
+
+// index adds the node to the lookup indices.
+func (g *gameState) index(node *Node) {
+	claim := node.self
+	g.claims[claim.ClaimData] = claim
+	g.nodes[claim.ClaimData] = node
+	g.byPosition[claim.Position] = append(g.byPosition[claim.Position], claim.ClaimData)
+	g.byDepth[claim.Depth()] = append(g.byDepth[claim.Depth()], claim.ClaimData)
 }
 
 // getParent returns the parent of the provided [Claim].
@@ -57,35 +90,11 @@ func (g *gameState) getParent(claim Claim) (Claim, error) {
 		return Claim{}, ErrClaimNotFound
 	}
 
-	// Walk down the tree from the root node to find the parent.
-	found, err := g.recurseTree(&g.root, claim.Parent)
-	if err != nil {
-		return Claim{}, err
-	}
-
-	// Return the parent of the found node.
-	return found.self, nil
-}
-
-// recurseTree recursively walks down the tree from the root node to find the
-// node with the provided [Claim].
-func (g *gameState) recurseTree(treeNode *Node, claim ClaimData) (*Node, error) {
-	// Check if the current node is the claim.
-	if treeNode.self.ClaimData == claim {
-		return treeNode, nil
-	}
-
-	// Check all children of the current node.
-	for _, child := range treeNode.children {
-		// Recurse and drop errors.
-		n, _ := g.recurseTree(child, claim)
-		if n != nil {
-			return n, nil
-		}
+	parent, ok := g.claims[claim.Parent]
+	if !ok {
+		return Claim{}, ErrClaimNotFound
 	}
-
-	// If we reach this point, the claim was not found.
-	return nil, ErrClaimNotFound
+	return parent, nil
 }
 
 // Put adds a claim into the game state.
@@ -95,20 +104,15 @@ func (g *gameState) Put(claim Claim) error {
 		return ErrClaimExists
 	}
 
-	// Grab the claim's parent.
-	parent := claim.Parent
-
-	// Walk down the tree from the root node to find the parent.
-	found, err := g.recurseTree(&g.root, parent)
-	if err != nil {
-		return err
+	// Look up the claim's parent.
+	found, ok := g.nodes[claim.Parent]
+	if !ok {
+		return ErrClaimNotFound
 	}
 
-	// Check that the node is not already in the tree.
-	for _, child := range found.children {
-		if child.self == claim {
-			return ErrClaimExists
-		}
+	// Check that the claim is not already in the tree.
+	if _, ok := g.claims[claim.ClaimData]; ok {
+		return ErrClaimExists
 	}
 
 	// Create a new node.
@@ -119,7 +123,7 @@ func (g *gameState) Put(claim Claim) error {
 
 	// Add the node to the tree.
 	found.children = append(found.children, &node)
-	g.claims[claim.ClaimData] = claim
+	g.index(&node)
 
 	return nil
 }
@@ -129,6 +133,60 @@ func (g *gameState) IsDuplicate(claim Claim) bool {
 	return ok
 }
 
+func (g *gameState) ClaimAtPosition(pos Position) (Claim, bool) {
+	claims := g.byPosition[pos]
+	if len(claims) == 0 {
+		return Claim{}, false
+	}
+	return g.claims[claims[0]], true
+}
+
+func (g *gameState) ClaimsAtDepth(depth int) []Claim {
+	var claims []Claim
+	for _, data := range g.byDepth[depth] {
+		claims = append(claims, g.claims[data])
+	}
+	return claims
+}
+
+func (g *gameState) AncestorWithTraceIndex(claim Claim, traceIndex uint64, maxDepth int) (Claim, bool) {
+	for {
+		if claim.TraceIndex(maxDepth) == traceIndex {
+			return claim, true
+		}
+		if claim.IsRoot() {
+			return Claim{}, false
+		}
+		parent, ok := g.claims[claim.Parent]
+		if !ok {
+			return Claim{}, false
+		}
+		claim = parent
+	}
+}
+
+func (g *gameState) WithClaims(claims []Claim) (Game, error) {
+	// Claims returns parents before their children, so they can be put in order.
+	existing := g.Claims()
+	out := NewGameState(existing[0])
+	for _, claim := range existing[1:] {
+		if err := out.Put(claim); err != nil {
+			return nil, err
+		}
+	}
+	for _, claim := range claims {
+		if node, ok := out.nodes[claim.ClaimData]; ok {
+			node.self = claim
+			out.claims[claim.ClaimData] = claim
+			continue
+		}
+		if err := out.Put(claim); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
 func (g *gameState) Claims() []Claim {
 	return g.root.claims()
 }
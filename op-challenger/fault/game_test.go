@@ -125,3 +125,89 @@ func TestGame_ClaimPairs(t *testing.T) {
 	claims := g.Claims()
 	require.ElementsMatch(t, expected, claims)
 }
+
+// TestGame_ClaimAtPosition tests the [Game.ClaimAtPosition] method using a [gameState] instance.
+func TestGame_ClaimAtPosition(t *testing.T) {
+	top, middle, bottom := createTestClaims()
+	g := NewGameState(top)
+	require.NoError(t, g.Put(middle))
+	require.NoError(t, g.Put(bottom))
+
+	// A second claim at the same position does not replace the first.
+	other := bottom
+	other.Value = common.Hash{0xaa}
+	require.NoError(t, g.Put(other))
+
+	claim, ok := g.ClaimAtPosition(middle.Position)
+	require.True(t, ok)
+	require.Equal(t, middle, claim)
+	claim, ok = g.ClaimAtPosition(bottom.Position)
+	require.True(t, ok)
+	require.Equal(t, bottom, claim)
+	_, ok = g.ClaimAtPosition(NewPosition(2, 0))
+	require.False(t, ok)
+}
+
+// TestGame_ClaimsAtDepth tests the [Game.ClaimsAtDepth] method using a [gameState] instance.
+func TestGame_ClaimsAtDepth(t *testing.T) {
+	top, middle, bottom := createTestClaims()
+	g := NewGameState(top)
+	require.NoError(t, g.Put(middle))
+	require.NoError(t, g.Put(bottom))
+	other := bottom
+	other.Value = common.Hash{0xaa}
+	require.NoError(t, g.Put(other))
+
+	require.Equal(t, []Claim{top}, g.ClaimsAtDepth(0))
+	require.Equal(t, []Claim{middle}, g.ClaimsAtDepth(1))
+	require.Equal(t, []Claim{bottom, other}, g.ClaimsAtDepth(2))
+	require.Empty(t, g.ClaimsAtDepth(3))
+}
+
+// TestGame_AncestorWithTraceIndex tests the [Game.AncestorWithTraceIndex] method using a [gameState] instance.
+func TestGame_AncestorWithTraceIndex(t *testing.T) {
+	maxDepth := 2
+	top, middle, bottom := createTestClaims()
+	g := NewGameState(top)
+	require.NoError(t, g.Put(middle))
+	require.NoError(t, g.Put(bottom))
+
+	// The claim itself is considered first.
+	claim, ok := g.AncestorWithTraceIndex(bottom, bottom.TraceIndex(maxDepth), maxDepth)
+	require.True(t, ok)
+	require.Equal(t, bottom, claim)
+
+	// The top and middle claims commit to the same trace index, the closest one is returned.
+	claim, ok = g.AncestorWithTraceIndex(bottom, top.TraceIndex(maxDepth), maxDepth)
+	require.True(t, ok)
+	require.Equal(t, middle, claim)
+
+	_, ok = g.AncestorWithTraceIndex(bottom, 0, maxDepth)
+	require.False(t, ok)
+}
+
+// TestGame_WithClaims tests that [Game.WithClaims] adds and replaces claims in a copy of the game.
+func TestGame_WithClaims(t *testing.T) {
+	top, middle, bottom := createTestClaims()
+	g := NewGameState(top)
+	require.NoError(t, g.Put(middle))
+
+	counteredMiddle := middle
+	counteredMiddle.Countered = true
+	updated, err := g.WithClaims([]Claim{counteredMiddle, bottom})
+	require.NoError(t, err)
+	require.Equal(t, []Claim{top, counteredMiddle, bottom}, updated.Claims())
+	claim, ok := updated.ClaimAtPosition(middle.Position)
+	require.True(t, ok)
+	require.Equal(t, counteredMiddle, claim)
+
+	// The original game is unchanged.
+	require.Equal(t, []Claim{top, middle}, g.Claims())
+
+	// Claims without a known parent are rejected.
+	orphan := bottom
+	orphan.Parent = ClaimData{Value: common.Hash{0xaa}}
+	orphan.Value = common.Hash{0xbb}
+	_, err = g.WithClaims([]Claim{orphan})
+	require.ErrorIs(t, err, ErrClaimNotFound)
+}
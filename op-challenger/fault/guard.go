@@ -0,0 +1,156 @@
+package fault
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ErrConflictingMove is returned when a move would post a different value at a position
+// that a value was already posted at in the same game.
+var ErrConflictingMove = errors.New("conflicting move: a different value was already posted at this position")
+
+type moveKey struct {
+	game common.Address
+	pos  Position
+}
+
+type postedMove struct {
+	Game  common.Address `json:"game"`
+	Claim ClaimData      `json:"claim"`
+}
+
+// MoveRecord remembers the value posted at every position of every game, so that the challenger
+// never posts two different values at the same position, e.g. after its trace changed.
+// A record opened with [OpenMoveRecord] is persisted to a file and survives restarts.
+type MoveRecord struct {
+	mu     sync.Mutex
+	posted map[moveKey]common.Hash
+	file   *os.File
+}
+
+// NewMoveRecord returns an in-memory [MoveRecord].
+func NewMoveRecord() *MoveRecord {
+	return &MoveRecord{posted: make(map[moveKey]common.Hash)}
+}
+
+// OpenMoveRecord opens the [MoveRecord] persisted at path, creating the file if it does not exist.
+// The file holds one JSON object per posted move and is only ever appended to.
+// An incomplete last line, e.g. from a crash while it was written, is removed, since the move
+// it recorded was not sent. Invalid complete lines are reported as corruption.
+func OpenMoveRecord(path string) (*MoveRecord, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open move record: %w", err)
+	}
+	data, err := readCompleteLines(file)
+	if err != nil {
+		_ = file.Close()
+		return nil, fmt.Errorf("failed to read move record: %w", err)
+	}
+	r := NewMoveRecord()
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for line := 1; scanner.Scan(); line++ {
+		var move postedMove
+		if err := json.Unmarshal(scanner.Bytes(), &move); err != nil {
+			_ = file.Close()
+			return nil, fmt.Errorf("invalid move record %v line %d: %w", path, line, err)
+		}
+		r.posted[moveKey{move.Game, move.Claim.Position}] = move.Claim.Value
+	}
+	if err := scanner.Err(); err != nil {
+		_ = file.Close()
+		return nil, fmt.Errorf("failed to read move record: %w", err)
+	}
+	r.file = file
+	return r, nil
+}
+
+// readCompleteLines reads the file and truncates it after its last newline, removing an incomplete
+// last line left by an interrupted write. It returns the remaining content.
+func readCompleteLines(file *os.File) ([]byte, error) {
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, err
+	}
+	end := bytes.LastIndexByte(data, '\n') + 1
+	if end < len(data) {
+		if err := file.Truncate(int64(end)); err != nil {
+			return nil, fmt.Errorf("failed to truncate incomplete line: %w", err)
+		}
+	}
+	return data[:end], nil
+}
+
+// Reserve records that the claim is about to be posted in the game. It returns [ErrConflictingMove]
+// if a different value was already posted at the position of the claim. Posting the same value
+// again, e.g. to retry a failed transaction, is allowed.
+// A persisted record is written to disk before Reserve returns.
+func (r *MoveRecord) Reserve(game common.Address, claim ClaimData) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := moveKey{game, claim.Position}
+	if value, ok := r.posted[key]; ok {
+		if value != claim.Value {
+			return fmt.Errorf("%w: game %v depth %d index %d posted %v, now %v",
+				ErrConflictingMove, game, claim.Depth(), claim.IndexAtDepth(), value, claim.Value)
+		}
+		return nil
+	}
+	if r.file != nil {
+		line, err := json.Marshal(postedMove{Game: game, Claim: claim})
+		if err != nil {
+			return err
+		}
+		if _, err := r.file.Write(append(line, '\n')); err != nil {
+			return fmt.Errorf("failed to write move record: %w", err)
+		}
+		if err := r.file.Sync(); err != nil {
+			return fmt.Errorf("failed to sync move record: %w", err)
+		}
+	}
+	r.posted[key] = claim.Value
+	return nil
+}
+
+// Close closes the file of a persisted record.
+func (r *MoveRecord) Close() error {
+	if r.file == nil {
+		return nil
+	}
+	return r.file.Close()
+}
+
+// GuardedResponder wraps a [Responder] and refuses to send moves that conflict with
+// the moves already posted in the game, according to the [MoveRecord].
+type GuardedResponder struct {
+	responder Responder
+	record    *MoveRecord
+	game      common.Address
+}
+
+// NewGuardedResponder returns a [GuardedResponder] for the game at gameAddr.
+func NewGuardedResponder(responder Responder, record *MoveRecord, gameAddr common.Address) *GuardedResponder {
+	return &GuardedResponder{
+		responder: responder,
+		record:    record,
+		game:      gameAddr,
+	}
+}
+
+// Respond reserves the position of the response in the record, then sends it.
+// The position stays reserved if sending fails, since the transaction may still be included.
+func (g *GuardedResponder) Respond(ctx context.Context, response Claim) error {
+	if err := g.record.Reserve(g.game, response.ClaimData); err != nil {
+		return err
+	}
+	return g.responder.Respond(ctx, response)
+}
@@ -0,0 +1,82 @@
+package fault
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+// TestGuardedResponder tests that a different value is never sent at the same position of a game.
+func TestGuardedResponder(t *testing.T) {
+	gameA := common.Address{0xaa}
+	gameB := common.Address{0xbb}
+	move := Claim{ClaimData: ClaimData{Value: common.Hash{0x01}, Position: NewPosition(1, 0)}}
+	conflict := Claim{ClaimData: ClaimData{Value: common.Hash{0x02}, Position: NewPosition(1, 0)}}
+
+	record := NewMoveRecord()
+	inner := &mockResponder{}
+	responder := NewGuardedResponder(inner, record, gameA)
+
+	require.NoError(t, responder.Respond(context.Background(), move))
+	require.NoError(t, responder.Respond(context.Background(), move), "should allow retrying the same move")
+	require.ErrorIs(t, responder.Respond(context.Background(), conflict), ErrConflictingMove)
+	require.Equal(t, []Claim{move, move}, inner.responses)
+
+	// Other games are unaffected.
+	require.NoError(t, NewGuardedResponder(inner, record, gameB).Respond(context.Background(), conflict))
+}
+
+// TestOpenMoveRecord tests that posted moves are persisted across restarts.
+func TestOpenMoveRecord(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "moves.jsonl")
+	game := common.Address{0xaa}
+	posted := ClaimData{Value: common.Hash{0x01}, Position: NewPosition(2, 3)}
+
+	record, err := OpenMoveRecord(path)
+	require.NoError(t, err)
+	require.NoError(t, record.Reserve(game, posted))
+	require.NoError(t, record.Close())
+
+	record, err = OpenMoveRecord(path)
+	require.NoError(t, err)
+	defer record.Close()
+	require.NoError(t, record.Reserve(game, posted))
+	require.ErrorIs(t, record.Reserve(game, ClaimData{Value: common.Hash{0x02}, Position: NewPosition(2, 3)}), ErrConflictingMove)
+	require.NoError(t, record.Reserve(game, ClaimData{Value: common.Hash{0x02}, Position: NewPosition(2, 2)}))
+
+	t.Run("Corrupt", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "moves.jsonl")
+		require.NoError(t, os.WriteFile(path, []byte("not json\n{}\n"), 0o600))
+		_, err := OpenMoveRecord(path)
+		require.ErrorContains(t, err, "line 1")
+	})
+
+	t.Run("TornLastLine", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "moves.jsonl")
+		record, err := OpenMoveRecord(path)
+		require.NoError(t, err)
+		require.NoError(t, record.Reserve(game, ClaimData{Value: common.Hash{0x01}, Position: NewPosition(1, 0)}))
+		require.NoError(t, record.Close())
+		file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0o600)
+		require.NoError(t, err)
+		_, err = file.WriteString(`{"game":"0x`)
+		require.NoError(t, err)
+		require.NoError(t, file.Close())
+
+		// The incomplete line is dropped and later moves are appended after the complete ones.
+		record, err = OpenMoveRecord(path)
+		require.NoError(t, err)
+		require.ErrorIs(t, record.Reserve(game, ClaimData{Value: common.Hash{0x02}, Position: NewPosition(1, 0)}), ErrConflictingMove)
+		require.NoError(t, record.Reserve(game, ClaimData{Value: common.Hash{0x02}, Position: NewPosition(2, 0)}))
+		require.NoError(t, record.Close())
+
+		record, err = OpenMoveRecord(path)
+		require.NoError(t, err)
+		require.ErrorIs(t, record.Reserve(game, ClaimData{Value: common.Hash{0x03}, Position: NewPosition(2, 0)}), ErrConflictingMove)
+		require.NoError(t, record.Close())
+	})
+}
@@ -0,0 +1,327 @@
+package fault
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-node/eth"
+)
+
+var (
+	ErrEventMismatch = errors.New("event does not match the claim in the contract")
+	ErrLogRemoved    = errors.New("move log was removed by an L1 reorg")
+)
+
+// HeaderFetcher is a minimal interface around [ethclient.Client] to look up canonical L1 blocks.
+type HeaderFetcher interface {
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+}
+
+// BlockRangeFetcher is a minimal interface around [sources.L1Client] to look up a range of canonical
+// L1 blocks with batched requests. A [HeaderFetcher] may implement it to speed up [ReorgedClaims].
+type BlockRangeFetcher interface {
+	L1BlockRefsByRange(ctx context.Context, start uint64, end uint64) ([]eth.L1BlockRef, error)
+}
+
+// maxBlockRange is the maximum span of blocks [ReorgedClaims] fetches as a single range.
+// Claims spread over more blocks are checked block by block, rather than fetching every block in between.
+const maxBlockRange = 256
+
+// ClaimFetcher is a minimal interface around [bindings.FaultDisputeGameCaller].
+// This needs to be updated if the [bindings.FaultDisputeGameCaller] interface changes.
+type ClaimFetcher interface {
+	ClaimData(opts *bind.CallOpts, arg0 *big.Int) (struct {
+		ParentIndex uint32
+		Countered   bool
+		Claim       [32]byte
+		Position    *big.Int
+		Clock       *big.Int
+	}, error)
+	ClaimDataLen(opts *bind.CallOpts) (*big.Int, error)
+}
+
+// Loader loads the claims of a fault dispute game from the contract.
+type Loader struct {
+	claimFetcher ClaimFetcher
+}
+
+// NewLoader creates a new [Loader].
+func NewLoader(claimFetcher ClaimFetcher) *Loader {
+	return &Loader{
+		claimFetcher: claimFetcher,
+	}
+}
+
+// fetchClaim fetches the claim at the given contract index, without its parent.
+func (l *Loader) fetchClaim(opts *bind.CallOpts, idx uint64) (Claim, uint32, error) {
+	data, err := l.claimFetcher.ClaimData(opts, new(big.Int).SetUint64(idx))
+	if err != nil {
+		return Claim{}, 0, fmt.Errorf("failed to fetch claim %d: %w", idx, err)
+	}
+	// The contract stores positions and clocks in wider integers. Values that do not fit must not be
+	// truncated, since the truncated value may alias a valid one.
+	if data.Position == nil || !data.Position.IsUint64() {
+		return Claim{}, 0, fmt.Errorf("%w: claim %d has position %v", ErrMalformedClaim, idx, data.Position)
+	}
+	if data.Clock != nil && (data.Clock.Sign() < 0 || data.Clock.BitLen() > 128) {
+		return Claim{}, 0, fmt.Errorf("%w: claim %d has clock %v", ErrMalformedClaim, idx, data.Clock)
+	}
+	position, err := positionFromGIndex(data.Position.Uint64(), BinaryBranching)
+	if err != nil {
+		return Claim{}, 0, fmt.Errorf("%w: claim %d: %v", ErrMalformedClaim, idx, err)
+	}
+	return Claim{
+		ClaimData: ClaimData{
+			Value:    data.Claim,
+			Position: position,
+		},
+		Countered:     data.Countered,
+		Clock:         NewClockFromBigInt(data.Clock),
+		ContractIndex: int(idx),
+	}, data.ParentIndex, nil
+}
+
+// FetchClaims fetches all claims of the game, in the order they were added to the contract.
+// The returned claims have their parent fields populated.
+func (l *Loader) FetchClaims(ctx context.Context) ([]Claim, error) {
+	return l.FetchClaimsWithOpts(&bind.CallOpts{Context: ctx})
+}
+
+// FetchClaimsWithOpts is like FetchClaims, but reads the contract with the given call options,
+// e.g. to pin the reads to a specific block.
+func (l *Loader) FetchClaimsWithOpts(opts *bind.CallOpts) ([]Claim, error) {
+	length, err := l.claimFetcher.ClaimDataLen(opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch claim count: %w", err)
+	}
+	claims := make([]Claim, 0, length.Uint64())
+	for i := uint64(0); i < length.Uint64(); i++ {
+		claim, parentIndex, err := l.fetchClaim(opts, i)
+		if err != nil {
+			return nil, err
+		}
+		if parentIndex != math.MaxUint32 {
+			if uint64(parentIndex) >= i {
+				return nil, fmt.Errorf("%w: claim %d has invalid parent index %d", ErrMalformedClaim, i, parentIndex)
+			}
+			claim.Parent = claims[parentIndex].ClaimData
+			claim.ParentContractIndex = int(parentIndex)
+		}
+		claims = append(claims, claim)
+	}
+	return claims, nil
+}
+
+// FetchAnnotatedClaims is like FetchClaimsWithOpts, but also sets the provenance of the claims
+// from the Move logs of the game up to the block of the call options.
+func (l *Loader) FetchAnnotatedClaims(opts *bind.CallOpts, filterer MoveFilterer) ([]Claim, error) {
+	claims, err := l.FetchClaimsWithOpts(opts)
+	if err != nil {
+		return nil, err
+	}
+	logs, err := FetchMoveLogs(opts.Context, filterer, opts.BlockNumber)
+	if err != nil {
+		return nil, err
+	}
+	return AnnotateClaims(claims, logs)
+}
+
+// ApplyEvents updates the game from the logs of the game contract, in the order they were emitted.
+// The game must hold exactly the claims of the contract before the logs, e.g. as loaded by [Loader.FetchClaims].
+// Move events do not include the position of the new claim, so only the claims the events add are
+// read from the contract, at the block of the event. Other events are ignored.
+// An [ErrEventMismatch] error means the game is out of sync with the contract and should be reloaded,
+// as does an [ErrLogRemoved] error, which means a Move event of the game was reorged out.
+func (l *Loader) ApplyEvents(ctx context.Context, game Game, logs []types.Log) (Game, error) {
+	moveLogs, err := filterMoveLogs(logs)
+	if err != nil {
+		return nil, err
+	}
+
+	byIndex := make(map[int]Claim)
+	for _, claim := range game.Claims() {
+		byIndex[claim.ContractIndex] = claim
+	}
+	var updates []Claim
+	for _, log := range moveLogs {
+		idx := uint64(len(byIndex))
+		opts := &bind.CallOpts{Context: ctx, BlockNumber: new(big.Int).SetUint64(log.BlockNumber)}
+		claim, claimParentIndex, err := l.fetchClaim(opts, idx)
+		if err != nil {
+			return nil, err
+		}
+		if !matchesMove(log, claim, claimParentIndex) {
+			return nil, fmt.Errorf("%w: claim %d", ErrEventMismatch, idx)
+		}
+		claim.Provenance = provenance(log)
+		parent, ok := byIndex[int(claimParentIndex)]
+		if !ok {
+			return nil, fmt.Errorf("%w: claim %d has unknown parent %d", ErrEventMismatch, idx, claimParentIndex)
+		}
+		parent.Countered = true
+		claim.Parent = parent.ClaimData
+		claim.ParentContractIndex = parent.ContractIndex
+		byIndex[parent.ContractIndex] = parent
+		byIndex[claim.ContractIndex] = claim
+		updates = append(updates, parent, claim)
+	}
+	return game.WithClaims(updates)
+}
+
+// AnnotateClaims sets the provenance of the claims from the Move logs of the game, e.g. as returned by
+// FilterMove for the whole game. The claims must be in contract order, as returned by [Loader.FetchClaims].
+// The i-th Move log added the claim at contract index i+1, as the root claim is added without a Move event.
+func AnnotateClaims(claims []Claim, logs []types.Log) ([]Claim, error) {
+	moveLogs, err := filterMoveLogs(logs)
+	if err != nil {
+		return nil, err
+	}
+	if len(moveLogs) >= len(claims) {
+		return nil, fmt.Errorf("%w: %d moves for %d claims", ErrEventMismatch, len(moveLogs), len(claims))
+	}
+	out := append([]Claim(nil), claims...)
+	for i, log := range moveLogs {
+		claim := &out[i+1]
+		if !matchesMove(log, *claim, uint32(claim.ParentContractIndex)) {
+			return nil, fmt.Errorf("%w: claim %d", ErrEventMismatch, claim.ContractIndex)
+		}
+		claim.Provenance = provenance(log)
+	}
+	return out, nil
+}
+
+// MoveFilterer is a minimal interface around [bindings.FaultDisputeGameFilterer].
+type MoveFilterer interface {
+	FilterMove(opts *bind.FilterOpts, parentIndex []*big.Int, pivot [][32]byte, claimant []common.Address) (*bindings.FaultDisputeGameMoveIterator, error)
+}
+
+// FetchMoveLogs returns the Move logs of the game up to and including the given L1 block, in order.
+// If end is nil, the logs up to the latest block are returned.
+func FetchMoveLogs(ctx context.Context, filterer MoveFilterer, end *big.Int) ([]types.Log, error) {
+	opts := &bind.FilterOpts{Context: ctx}
+	if end != nil {
+		number := end.Uint64()
+		opts.End = &number
+	}
+	iter, err := filterer.FilterMove(opts, nil, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch move events: %w", err)
+	}
+	defer iter.Close()
+	var logs []types.Log
+	for iter.Next() {
+		logs = append(logs, iter.Event.Raw)
+	}
+	if err := iter.Error(); err != nil {
+		return nil, fmt.Errorf("failed to fetch move events: %w", err)
+	}
+	return logs, nil
+}
+
+// filterMoveLogs returns the Move logs of the game, in order.
+func filterMoveLogs(logs []types.Log) ([]types.Log, error) {
+	fdgAbi, err := bindings.FaultDisputeGameMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	moveID := fdgAbi.Events["Move"].ID
+	var out []types.Log
+	for _, log := range logs {
+		if len(log.Topics) != 4 || log.Topics[0] != moveID {
+			continue
+		}
+		if log.Removed {
+			return nil, fmt.Errorf("%w: tx %v in block %d", ErrLogRemoved, log.TxHash, log.BlockNumber)
+		}
+		out = append(out, log)
+	}
+	return out, nil
+}
+
+// matchesMove returns true if the Move log added the claim with the given parent index.
+func matchesMove(log types.Log, claim Claim, parentIndex uint32) bool {
+	logParentIndex := log.Topics[1].Big()
+	return logParentIndex.IsUint64() && logParentIndex.Uint64() == uint64(parentIndex) && claim.Value == log.Topics[2]
+}
+
+func provenance(log types.Log) Provenance {
+	return Provenance{
+		L1Block:     log.BlockNumber,
+		L1BlockHash: log.BlockHash,
+		TxHash:      log.TxHash,
+		Claimant:    common.BytesToAddress(log.Topics[3].Bytes()),
+	}
+}
+
+// ReorgedClaims returns the claims whose Move event is in a block that is no longer canonical.
+// Claims without a provenance are not checked. If any claims are returned, the game state built
+// from them is stale and the game should be reloaded from the contract.
+// If headers is a [BlockRangeFetcher] and the blocks of the claims are close together, they are fetched as a single range.
+func ReorgedClaims(ctx context.Context, headers HeaderFetcher, claims []Claim) ([]Claim, error) {
+	canonical, err := canonicalRange(ctx, headers, claims)
+	if err != nil {
+		return nil, err
+	}
+	var reorged []Claim
+	for _, claim := range claims {
+		if claim.Provenance == (Provenance{}) {
+			continue
+		}
+		number := claim.Provenance.L1Block
+		hash, ok := canonical[number]
+		if !ok {
+			header, err := headers.HeaderByNumber(ctx, new(big.Int).SetUint64(number))
+			if err != nil {
+				return nil, fmt.Errorf("failed to fetch L1 block %d: %w", number, err)
+			}
+			hash = header.Hash()
+			canonical[number] = hash
+		}
+		if hash != claim.Provenance.L1BlockHash {
+			reorged = append(reorged, claim)
+		}
+	}
+	return reorged, nil
+}
+
+// canonicalRange returns the canonical hashes of the blocks of the claims, fetched as a single range,
+// if headers is a [BlockRangeFetcher] and the blocks span at most maxBlockRange blocks.
+// Otherwise, an empty map is returned, so that each block is looked up separately.
+func canonicalRange(ctx context.Context, headers HeaderFetcher, claims []Claim) (map[uint64]common.Hash, error) {
+	canonical := make(map[uint64]common.Hash)
+	ranges, ok := headers.(BlockRangeFetcher)
+	if !ok {
+		return canonical, nil
+	}
+	start, end := uint64(math.MaxUint64), uint64(0)
+	for _, claim := range claims {
+		if claim.Provenance == (Provenance{}) {
+			continue
+		}
+		if claim.Provenance.L1Block < start {
+			start = claim.Provenance.L1Block
+		}
+		if claim.Provenance.L1Block > end {
+			end = claim.Provenance.L1Block
+		}
+	}
+	if start > end || end-start >= maxBlockRange {
+		return canonical, nil
+	}
+	refs, err := ranges.L1BlockRefsByRange(ctx, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch L1 blocks %d to %d: %w", start, end, err)
+	}
+	for _, ref := range refs {
+		canonical[ref.Number] = ref.Hash
+	}
+	return canonical, nil
+}
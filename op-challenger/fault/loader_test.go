@@ -0,0 +1,311 @@
+package fault
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-node/eth"
+)
+
+var mockClaimFetchError = errors.New("mock claim fetch error")
+
+type mockClaimData struct {
+	ParentIndex uint32
+	Countered   bool
+	Claim       [32]byte
+	Position    *big.Int
+	Clock       *big.Int
+}
+
+type mockClaimFetcher struct {
+	claims    []mockClaimData
+	returnErr bool
+}
+
+func (m *mockClaimFetcher) ClaimData(opts *bind.CallOpts, idx *big.Int) (struct {
+	ParentIndex uint32
+	Countered   bool
+	Claim       [32]byte
+	Position    *big.Int
+	Clock       *big.Int
+}, error) {
+	if m.returnErr {
+		return mockClaimData{}, mockClaimFetchError
+	}
+	return m.claims[idx.Uint64()], nil
+}
+
+func (m *mockClaimFetcher) ClaimDataLen(opts *bind.CallOpts) (*big.Int, error) {
+	return big.NewInt(int64(len(m.claims))), nil
+}
+
+// TestLoader_FetchClaims_Succeeds tests [Loader.FetchClaims].
+func TestLoader_FetchClaims_Succeeds(t *testing.T) {
+	root := NewPosition(0, 0)
+	attack := root.Attack()
+	defend := attack.Defend()
+	fetcher := &mockClaimFetcher{
+		claims: []mockClaimData{
+			{ParentIndex: math.MaxUint32, Claim: common.Hash{0x01}, Position: new(big.Int).SetUint64(root.ToGIndex())},
+			{ParentIndex: 0, Countered: true, Claim: common.Hash{0x02}, Position: new(big.Int).SetUint64(attack.ToGIndex())},
+			{ParentIndex: 1, Claim: common.Hash{0x03}, Position: new(big.Int).SetUint64(defend.ToGIndex()), Clock: Clock{Duration: 5, Timestamp: 1000}.BigInt()},
+		},
+	}
+	loader := NewLoader(fetcher)
+	claims, err := loader.FetchClaims(context.Background())
+	require.NoError(t, err)
+
+	rootData := ClaimData{Value: common.Hash{0x01}, Position: root}
+	attackData := ClaimData{Value: common.Hash{0x02}, Position: attack}
+	require.Equal(t, []Claim{
+		{ClaimData: rootData},
+		{ClaimData: attackData, Countered: true, Parent: rootData, ContractIndex: 1},
+		{ClaimData: ClaimData{Value: common.Hash{0x03}, Position: defend}, Clock: Clock{Duration: 5, Timestamp: 1000}, Parent: attackData, ContractIndex: 2, ParentContractIndex: 1},
+	}, claims)
+}
+
+// TestLoader_FetchClaims_InvalidParent tests that claims referencing
+// a parent that was not added before them are rejected.
+func TestLoader_FetchClaims_InvalidParent(t *testing.T) {
+	fetcher := &mockClaimFetcher{
+		claims: []mockClaimData{
+			{ParentIndex: math.MaxUint32, Position: big.NewInt(1)},
+			{ParentIndex: 1, Position: big.NewInt(2)},
+		},
+	}
+	_, err := NewLoader(fetcher).FetchClaims(context.Background())
+	require.ErrorIs(t, err, ErrMalformedClaim)
+	require.ErrorContains(t, err, "invalid parent index")
+}
+
+// TestLoader_FetchClaims_Malformed tests that positions and clocks that do not fit are rejected
+// instead of being truncated.
+func TestLoader_FetchClaims_Malformed(t *testing.T) {
+	aliased := new(big.Int).Add(new(big.Int).Lsh(big.NewInt(1), 64), big.NewInt(1))
+	tests := []struct {
+		name  string
+		claim mockClaimData
+	}{
+		{"PositionOver64Bits", mockClaimData{ParentIndex: math.MaxUint32, Position: aliased}},
+		{"NoPosition", mockClaimData{ParentIndex: math.MaxUint32}},
+		{"ZeroPosition", mockClaimData{ParentIndex: math.MaxUint32, Position: big.NewInt(0)}},
+		{"ClockOver128Bits", mockClaimData{ParentIndex: math.MaxUint32, Position: big.NewInt(1), Clock: new(big.Int).Lsh(big.NewInt(1), 128)}},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			_, err := NewLoader(&mockClaimFetcher{claims: []mockClaimData{test.claim}}).FetchClaims(context.Background())
+			require.ErrorIs(t, err, ErrMalformedClaim)
+		})
+	}
+}
+
+// TestLoader_FetchClaims_ClaimDataErrors tests that fetch errors are returned.
+func TestLoader_FetchClaims_ClaimDataErrors(t *testing.T) {
+	fetcher := &mockClaimFetcher{
+		claims:    []mockClaimData{{ParentIndex: math.MaxUint32, Position: big.NewInt(1)}},
+		returnErr: true,
+	}
+	_, err := NewLoader(fetcher).FetchClaims(context.Background())
+	require.ErrorIs(t, err, mockClaimFetchError)
+}
+
+func moveLog(t *testing.T, parentIndex int64, pivot common.Hash) types.Log {
+	fdgAbi, err := bindings.FaultDisputeGameMetaData.GetAbi()
+	require.NoError(t, err)
+	return types.Log{
+		BlockNumber: 7,
+		TxHash:      common.Hash{0xdd},
+		Topics: []common.Hash{
+			fdgAbi.Events["Move"].ID,
+			common.BigToHash(big.NewInt(parentIndex)),
+			pivot,
+			common.BytesToHash(common.Address{0xcc}.Bytes()),
+		},
+	}
+}
+
+// TestLoader_ApplyEvents tests that Move events add the new claims and counter their parents.
+func TestLoader_ApplyEvents(t *testing.T) {
+	root := NewPosition(0, 0)
+	attack := root.Attack()
+	fetcher := &mockClaimFetcher{
+		claims: []mockClaimData{
+			{ParentIndex: math.MaxUint32, Claim: common.Hash{0x01}, Position: new(big.Int).SetUint64(root.ToGIndex())},
+		},
+	}
+	loader := NewLoader(fetcher)
+	claims, err := loader.FetchClaims(context.Background())
+	require.NoError(t, err)
+	game := NewGameState(claims[0])
+
+	fetcher.claims = append(fetcher.claims, mockClaimData{ParentIndex: 0, Claim: common.Hash{0x02}, Position: new(big.Int).SetUint64(attack.ToGIndex())})
+	updated, err := loader.ApplyEvents(context.Background(), game, []types.Log{
+		moveLog(t, 0, common.Hash{0x02}),
+		{Topics: []common.Hash{{0xee}}},
+	})
+	require.NoError(t, err)
+
+	rootData := ClaimData{Value: common.Hash{0x01}, Position: root}
+	require.Equal(t, []Claim{
+		{ClaimData: rootData, Countered: true},
+		{ClaimData: ClaimData{Value: common.Hash{0x02}, Position: attack}, Parent: rootData, ContractIndex: 1, Provenance: Provenance{L1Block: 7, TxHash: common.Hash{0xdd}, Claimant: common.Address{0xcc}}},
+	}, updated.Claims())
+	require.Equal(t, []Claim{claims[0]}, game.Claims(), "the original game is unchanged")
+}
+
+// TestLoader_ApplyEvents_Mismatch tests that events that do not match the contract are rejected.
+func TestLoader_ApplyEvents_Mismatch(t *testing.T) {
+	root := NewPosition(0, 0)
+	attack := root.Attack()
+	fetcher := &mockClaimFetcher{
+		claims: []mockClaimData{
+			{ParentIndex: math.MaxUint32, Claim: common.Hash{0x01}, Position: new(big.Int).SetUint64(root.ToGIndex())},
+			{ParentIndex: 0, Claim: common.Hash{0x02}, Position: new(big.Int).SetUint64(attack.ToGIndex())},
+		},
+	}
+	game := NewGameState(Claim{ClaimData: ClaimData{Value: common.Hash{0x01}, Position: root}})
+	_, err := NewLoader(fetcher).ApplyEvents(context.Background(), game, []types.Log{moveLog(t, 0, common.Hash{0x03})})
+	require.ErrorIs(t, err, ErrEventMismatch)
+}
+
+// TestAnnotateClaims tests that the provenance of claims is set from their Move logs.
+func TestAnnotateClaims(t *testing.T) {
+	rootData := ClaimData{Value: common.Hash{0x01}, Position: NewPosition(0, 0)}
+	claims := []Claim{
+		{ClaimData: rootData},
+		{ClaimData: ClaimData{Value: common.Hash{0x02}, Position: NewPosition(1, 0)}, Parent: rootData, ContractIndex: 1},
+	}
+
+	annotated, err := AnnotateClaims(claims, []types.Log{moveLog(t, 0, common.Hash{0x02})})
+	require.NoError(t, err)
+	require.Equal(t, Provenance{}, annotated[0].Provenance)
+	require.Equal(t, Provenance{L1Block: 7, TxHash: common.Hash{0xdd}, Claimant: common.Address{0xcc}}, annotated[1].Provenance)
+	require.Equal(t, Provenance{}, claims[1].Provenance, "the input claims are unchanged")
+
+	_, err = AnnotateClaims(claims, []types.Log{moveLog(t, 0, common.Hash{0x03})})
+	require.ErrorIs(t, err, ErrEventMismatch)
+	_, err = AnnotateClaims(claims, []types.Log{moveLog(t, 0, common.Hash{0x02}), moveLog(t, 1, common.Hash{0x03})})
+	require.ErrorIs(t, err, ErrEventMismatch)
+}
+
+// TestLoader_ApplyEvents_Removed tests that removed Move logs require the game to be reloaded.
+func TestLoader_ApplyEvents_Removed(t *testing.T) {
+	game := NewGameState(Claim{ClaimData: ClaimData{Value: common.Hash{0x01}, Position: NewPosition(0, 0)}})
+	log := moveLog(t, 0, common.Hash{0x02})
+	log.Removed = true
+	_, err := NewLoader(&mockClaimFetcher{}).ApplyEvents(context.Background(), game, []types.Log{log})
+	require.ErrorIs(t, err, ErrLogRemoved)
+}
+
+type mockHeaderFetcher struct {
+	headers map[uint64]*types.Header
+	latest  *types.Header
+	calls   int
+}
+
+func (m *mockHeaderFetcher) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	m.calls++
+	if number == nil {
+		return m.latest, nil
+	}
+	header, ok := m.headers[number.Uint64()]
+	if !ok {
+		return nil, mockClaimFetchError
+	}
+	return header, nil
+}
+
+// TestReorgedClaims tests that claims from blocks that are no longer canonical are reported.
+func TestReorgedClaims(t *testing.T) {
+	canonical := &types.Header{Number: big.NewInt(7)}
+	replaced := &types.Header{Number: big.NewInt(8)}
+	headers := &mockHeaderFetcher{headers: map[uint64]*types.Header{7: canonical, 8: replaced}}
+
+	root := Claim{ClaimData: ClaimData{Value: common.Hash{0x01}, Position: NewPosition(0, 0)}}
+	attack := Claim{
+		ClaimData:  ClaimData{Value: common.Hash{0x02}, Position: NewPosition(1, 0)},
+		Provenance: Provenance{L1Block: 7, L1BlockHash: canonical.Hash()},
+	}
+	sameBlock := Claim{
+		ClaimData:  ClaimData{Value: common.Hash{0x03}, Position: NewPosition(1, 0)},
+		Provenance: Provenance{L1Block: 7, L1BlockHash: canonical.Hash()},
+	}
+	reorged := Claim{
+		ClaimData:  ClaimData{Value: common.Hash{0x04}, Position: NewPosition(2, 0)},
+		Provenance: Provenance{L1Block: 8, L1BlockHash: common.Hash{0xee}},
+	}
+
+	out, err := ReorgedClaims(context.Background(), headers, []Claim{root, attack, sameBlock, reorged})
+	require.NoError(t, err)
+	require.Equal(t, []Claim{reorged}, out)
+	require.Equal(t, 2, headers.calls, "each block is fetched once")
+
+	missing := reorged
+	missing.Provenance.L1Block = 9
+	_, err = ReorgedClaims(context.Background(), headers, []Claim{missing})
+	require.ErrorIs(t, err, mockClaimFetchError)
+}
+
+// mockBlockRangeFetcher serves the headers of mockHeaderFetcher as ranges too.
+type mockBlockRangeFetcher struct {
+	*mockHeaderFetcher
+	rangeCalls int
+}
+
+func (m *mockBlockRangeFetcher) L1BlockRefsByRange(ctx context.Context, start uint64, end uint64) ([]eth.L1BlockRef, error) {
+	m.rangeCalls++
+	var refs []eth.L1BlockRef
+	for n := start; n <= end; n++ {
+		header, ok := m.headers[n]
+		if !ok {
+			return nil, mockClaimFetchError
+		}
+		refs = append(refs, eth.L1BlockRef{Hash: header.Hash(), Number: n})
+	}
+	return refs, nil
+}
+
+// TestReorgedClaims_BlockRange tests that the blocks of claims that are close together are fetched as one range.
+func TestReorgedClaims_BlockRange(t *testing.T) {
+	canonical := &types.Header{Number: big.NewInt(7)}
+	next := &types.Header{Number: big.NewInt(8)}
+	distant := &types.Header{Number: big.NewInt(7 + maxBlockRange)}
+	headers := &mockBlockRangeFetcher{mockHeaderFetcher: &mockHeaderFetcher{headers: map[uint64]*types.Header{
+		7: canonical, 8: next, 7 + maxBlockRange: distant,
+	}}}
+
+	attack := Claim{
+		ClaimData:  ClaimData{Value: common.Hash{0x02}, Position: NewPosition(1, 0)},
+		Provenance: Provenance{L1Block: 7, L1BlockHash: canonical.Hash()},
+	}
+	reorged := Claim{
+		ClaimData:  ClaimData{Value: common.Hash{0x04}, Position: NewPosition(2, 0)},
+		Provenance: Provenance{L1Block: 8, L1BlockHash: common.Hash{0xee}},
+	}
+	out, err := ReorgedClaims(context.Background(), headers, []Claim{attack, reorged})
+	require.NoError(t, err)
+	require.Equal(t, []Claim{reorged}, out)
+	require.Equal(t, 1, headers.rangeCalls)
+	require.Zero(t, headers.calls)
+
+	// Blocks too far apart are fetched one by one.
+	far := Claim{
+		ClaimData:  ClaimData{Value: common.Hash{0x05}, Position: NewPosition(2, 1)},
+		Provenance: Provenance{L1Block: 7 + maxBlockRange, L1BlockHash: distant.Hash()},
+	}
+	out, err = ReorgedClaims(context.Background(), headers, []Claim{attack, far})
+	require.NoError(t, err)
+	require.Empty(t, out)
+	require.Equal(t, 1, headers.rangeCalls)
+	require.Equal(t, 2, headers.calls)
+}
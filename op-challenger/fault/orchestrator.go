@@ -2,28 +2,46 @@ package fault
 
 import (
 	"context"
+	"errors"
 	"os"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
 )
 
 type Orchestrator struct {
 	agents    []Agent
 	outputChs []chan Claim
 	responses chan Claim
+
+	audit  TraceAuditConfig
+	audits []*AuditingTraceProvider
 }
 
-func NewOrchestrator(maxDepth uint64, traces []TraceProvider, names []string, root Claim) Orchestrator {
+// NewOrchestrator creates an [Orchestrator] playing a game in memory between agents with the given traces.
+// The responses of all agents are subject to the controls. The in-memory game has no contract address,
+// so it is identified by the zero address in the controls. The agents record their solver metrics to m.
+// If audits are enabled, the trace of every agent is audited for determinism in the background once started.
+func NewOrchestrator(maxDepth uint64, traces []TraceProvider, names []string, root Claim, controls *Controls, m metrics.Metricer, audit TraceAuditConfig) Orchestrator {
 	o := Orchestrator{
 		responses: make(chan Claim, 100),
 		outputChs: make([]chan Claim, len(traces)),
 		agents:    make([]Agent, len(traces)),
+		audit:     audit,
 	}
 	log.Info("Starting game", "root_letter", string(root.Value[31:]))
+	responder := controls.Responder(common.Address{}, &o)
 	for i, trace := range traces {
+		if audit.Enabled() {
+			auditing := NewAuditingTraceProvider(trace, log.New("role", names[i]), m)
+			o.audits = append(o.audits, auditing)
+			trace = auditing
+		}
 		game := NewGameState(root)
-		o.agents[i] = NewAgent(game, int(maxDepth), trace, &o, log.New("role", names[i]))
+		o.agents[i] = NewAgent(game, common.Address{}, int(maxDepth), trace, responder, log.New("role", names[i]), m)
 		o.outputChs[i] = make(chan Claim)
 	}
 	return o
@@ -35,19 +53,30 @@ func (o *Orchestrator) Respond(_ context.Context, response Claim) error {
 }
 
 func (o *Orchestrator) Start() {
+	for _, audit := range o.audits {
+		go audit.RunAudits(context.Background(), o.audit.Interval, o.audit.SampleSize)
+	}
 	for i := 0; i < len(o.agents); i++ {
 		go runAgent(&o.agents[i], o.outputChs[i])
 	}
 	o.responderThread()
 }
 
+// runAgent plays the game with the agent until it is quarantined. Both performing actions and adding
+// claims are guarded, so a panic in either only quarantines this agent.
 func runAgent(agent *Agent, claimCh <-chan Claim) {
 	for {
-		agent.PerformActions()
+		if err := agent.TryPerformActions(); err != nil {
+			break
+		}
 		// Note: Should drain the channel here
 		claim := <-claimCh
-		_ = agent.AddClaim(claim)
-
+		if err := agent.TryAddClaim(claim); errors.Is(err, ErrAgentPanicked) {
+			break
+		}
+	}
+	// Keep receiving claims, so a quarantined agent does not block the responder thread.
+	for range claimCh {
 	}
 }
 
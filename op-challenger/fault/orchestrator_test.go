@@ -0,0 +1,55 @@
+package fault
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+)
+
+// orchestratorMetrics records the solver metrics of the agents and ignores the rest.
+type orchestratorMetrics struct {
+	metrics.Metricer
+	agent *mockAgentMetrics
+}
+
+func (m *orchestratorMetrics) RecordSolverTick(game common.Address, claims int, duration time.Duration) {
+	m.agent.RecordSolverTick(game, claims, duration)
+}
+
+func (m *orchestratorMetrics) RecordSolverDecision(decision string, reason string) {
+	m.agent.RecordSolverDecision(decision, reason)
+}
+
+func (m *orchestratorMetrics) RecordAgentPanic() {
+	m.agent.RecordAgentPanic()
+}
+
+// TestOrchestrator_RecordsAgentMetrics tests that the agents of the orchestrator record to its metrics.
+func TestOrchestrator_RecordsAgentMetrics(t *testing.T) {
+	agent := &mockAgentMetrics{decisions: make(map[string]int)}
+	m := &orchestratorMetrics{Metricer: metrics.NoopMetrics, agent: agent}
+	root := Claim{ClaimData: ClaimData{Value: common.Hash{0xff}, Position: NewPosition(0, 0)}}
+	o := NewOrchestrator(3, []TraceProvider{NewAlphabetProvider("abcdefgh", 3)}, []string{"honest"}, root, NewControls(), m, TraceAuditConfig{})
+
+	require.NoError(t, o.agents[0].TryPerformActions())
+	require.Equal(t, []int{1}, agent.ticks)
+	require.NotEmpty(t, agent.decisions)
+}
+
+// TestOrchestrator_AuditsTraces tests that the traces of the agents are audited if enabled.
+func TestOrchestrator_AuditsTraces(t *testing.T) {
+	root := Claim{ClaimData: ClaimData{Value: common.Hash{0xff}, Position: NewPosition(0, 0)}}
+	traces := []TraceProvider{NewAlphabetProvider("abcdefgh", 3), NewAlphabetProvider("abcdexyz", 3)}
+	o := NewOrchestrator(3, traces, []string{"a", "b"}, root, NewControls(), metrics.NoopMetrics, TraceAuditConfig{})
+	require.Empty(t, o.audits)
+
+	o = NewOrchestrator(3, traces, []string{"a", "b"}, root, NewControls(), metrics.NoopMetrics, TraceAuditConfig{Interval: time.Second, SampleSize: 2})
+	require.Len(t, o.audits, 2)
+	require.NoError(t, o.agents[0].TryPerformActions())
+	require.NoError(t, o.audits[0].Audit(2))
+	require.NotEmpty(t, o.audits[0].indices)
+}
@@ -1,21 +1,133 @@
 package fault
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+)
+
+// BinaryBranching is the branching factor of games that bisect the trace.
+const BinaryBranching = 2
+
+// ErrInvalidPosition is returned for positions that do not exist in a game, e.g. an index at depth
+// beyond the width of the tree at that depth, or a generalized index that does not fit in 64 bits.
+var ErrInvalidPosition = errors.New("invalid position")
 
 // Position is a golang wrapper around the dispute game Position type.
+// The zero value of branching is treated as [BinaryBranching].
 type Position struct {
 	depth        int
 	indexAtDepth int
+	branching    int
 }
 
+// NewPosition returns a position in a binary game. It panics if the position is invalid.
 func NewPosition(depth, indexAtDepth int) Position {
-	return Position{depth, indexAtDepth}
+	return NewPositionWithBranching(depth, indexAtDepth, BinaryBranching)
+}
+
+// NewPositionWithBranching returns a position in a game where every claim has branching children.
+// It panics if branching is less than [BinaryBranching] or the position does not exist in the game.
+func NewPositionWithBranching(depth, indexAtDepth, branching int) Position {
+	p, err := newPosition(depth, indexAtDepth, branching)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// newPosition is like NewPositionWithBranching, but returns an error for invalid positions.
+func newPosition(depth, indexAtDepth, branching int) (Position, error) {
+	if err := checkPosition(depth, indexAtDepth, branching); err != nil {
+		return Position{}, err
+	}
+	if branching == BinaryBranching {
+		// Keep binary positions comparable with the ones created by NewPosition.
+		branching = 0
+	}
+	return Position{depth: depth, indexAtDepth: indexAtDepth, branching: branching}, nil
 }
 
+// checkPosition checks that the position exists in a game with the given branching factor,
+// and that its generalized index fits in 64 bits.
+func checkPosition(depth, indexAtDepth, branching int) error {
+	if branching < BinaryBranching {
+		return fmt.Errorf("%w: branching factor %d is less than %d", ErrInvalidPosition, branching, BinaryBranching)
+	}
+	if depth < 0 || indexAtDepth < 0 {
+		return fmt.Errorf("%w: negative depth %d or index %d", ErrInvalidPosition, depth, indexAtDepth)
+	}
+	k := uint64(branching)
+	width, ok := pow(k, depth)
+	// The generalized index of the last position at depth is 1 + k + ... + k^depth.
+	if ok {
+		first := (width-1)/(k-1) + 1
+		ok = first <= math.MaxUint64-(width-1)
+	}
+	if !ok {
+		return fmt.Errorf("%w: depth %d is too deep for branching factor %d", ErrInvalidPosition, depth, branching)
+	}
+	if uint64(indexAtDepth) >= width {
+		return fmt.Errorf("%w: index %d at depth %d exceeds the %d positions at that depth", ErrInvalidPosition, indexAtDepth, depth, width)
+	}
+	return nil
+}
+
+// NewPositionFromGIndex is the inverse of [Position.ToGIndex] for a binary game.
+// It panics if x is 0, which is not a generalized index.
 func NewPositionFromGIndex(x uint64) Position {
-	depth := MSBIndex(x)
-	indexAtDepth := ^(1 << depth) & x
-	return NewPosition(depth, int(indexAtDepth))
+	return NewPositionWithBranchingFromGIndex(x, BinaryBranching)
+}
+
+// NewPositionWithBranchingFromGIndex is the inverse of [Position.ToGIndex] for a game with the given branching factor.
+// It panics if x is not a valid generalized index, or branching is less than [BinaryBranching].
+func NewPositionWithBranchingFromGIndex(x uint64, branching int) Position {
+	p, err := positionFromGIndex(x, branching)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// positionFromGIndex is like NewPositionWithBranchingFromGIndex, but returns an error for invalid indices.
+func positionFromGIndex(x uint64, branching int) (Position, error) {
+	if branching < BinaryBranching {
+		return Position{}, fmt.Errorf("%w: branching factor %d is less than %d", ErrInvalidPosition, branching, BinaryBranching)
+	}
+	if x == 0 {
+		return Position{}, fmt.Errorf("%w: generalized index 0", ErrInvalidPosition)
+	}
+	if branching == BinaryBranching {
+		depth := MSBIndex(x)
+		return newPosition(depth, int(x&^(uint64(1)<<depth)), branching)
+	}
+	k := uint64(branching)
+	depth := 0
+	// first is the generalized index of the first position at depth.
+	first, width := uint64(1), uint64(1)
+	for x-first >= width {
+		first += width
+		depth++
+		hi, lo := bits.Mul64(width, k)
+		if hi != 0 {
+			// The next level is wider than any remaining index, so x is at this depth.
+			break
+		}
+		width = lo
+	}
+	if x-first > math.MaxInt64 {
+		return Position{}, fmt.Errorf("%w: generalized index %d is too large", ErrInvalidPosition, x)
+	}
+	return newPosition(depth, int(x-first), branching)
+}
+
+// Branching returns the number of children of every position in the game.
+func (p *Position) Branching() int {
+	if p.branching == 0 {
+		return BinaryBranching
+	}
+	return p.branching
 }
 
 func (p *Position) Depth() int {
@@ -33,42 +145,62 @@ func (p *Position) IsRootPosition() bool {
 // TraceIndex calculates the what the index of the claim value would be inside the trace.
 // It is equivalent to going right until the final depth has been reached.
 func (p *Position) TraceIndex(maxDepth int) uint64 {
-	// When we go right, we do a shift left and set the bottom bit to be 1.
-	// To do this in a single step, do all the shifts at once & or in all 1s for the bottom bits.
 	rd := maxDepth - p.depth
-	return uint64(p.indexAtDepth<<rd | ((1 << rd) - 1))
+	if p.Branching() == BinaryBranching {
+		// When we go right, we do a shift left and set the bottom bit to be 1.
+		// To do this in a single step, do all the shifts at once & or in all 1s for the bottom bits.
+		return uint64(p.indexAtDepth<<rd | ((1 << rd) - 1))
+	}
+	// Going to the rightmost child multiplies by the branching factor and adds branching-1,
+	// so after rd steps the index is scaled by branching^rd plus all the added offsets.
+	width, ok := pow(uint64(p.Branching()), rd)
+	if !ok {
+		panic(fmt.Errorf("%w: trace index at max depth %d overflows", ErrInvalidPosition, maxDepth))
+	}
+	return uint64(p.indexAtDepth)*width + width - 1
 }
 
 // move goes to the left or right child.
 func (p *Position) move(right bool) {
-	p.depth++
-	p.indexAtDepth = (p.indexAtDepth << 1) | boolToInt(right)
+	child := 0
+	if right {
+		child = p.Branching() - 1
+	}
+	p.moveTo(child)
 }
 
-func boolToInt(b bool) int {
-	if b {
-		return 1
-	} else {
-		return 0
-	}
+// moveTo goes to the child with the given index, counting from the left.
+func (p *Position) moveTo(child int) {
+	p.depth++
+	p.indexAtDepth = p.indexAtDepth*p.Branching() + child
 }
 
 // parent moves up to the parent.
 func (p *Position) parent() {
 	p.depth--
-	p.indexAtDepth = p.indexAtDepth >> 1
+	p.indexAtDepth = p.indexAtDepth / p.Branching()
 }
 
 // Attack creates a new position which is the attack position of this one.
 func (p *Position) Attack() Position {
-	p2 := NewPosition(p.depth, p.indexAtDepth)
+	p2 := *p
 	p2.move(false)
 	return p2
 }
 
+// Child creates a new position which is the child of this one with the given index, counting from the left.
+// In a binary game child 0 is the attack position.
+func (p *Position) Child(index int) Position {
+	p2 := *p
+	p2.moveTo(index)
+	return p2
+}
+
 // Defend creates a new position which is the defend position of this one.
+// In games with more than two children per claim this is the leftmost child
+// of the rightmost sibling of this position.
 func (p *Position) Defend() Position {
-	p2 := NewPosition(p.depth, p.indexAtDepth)
+	p2 := *p
 	p2.parent()
 	p2.move(true)
 	p2.move(false)
@@ -79,8 +211,33 @@ func (p *Position) Print(maxDepth int) {
 	fmt.Printf("GIN: %4b\tTrace Position is %4b\tTrace Depth is: %d\tTrace Index is: %d\n", p.ToGIndex(), p.indexAtDepth, p.depth, p.TraceIndex(maxDepth))
 }
 
+// ToGIndex returns the generalized index of the position, numbering the positions
+// of the tree from 1 level by level, left to right.
 func (p *Position) ToGIndex() uint64 {
-	return uint64(1<<p.depth | p.indexAtDepth)
+	if err := checkPosition(p.depth, p.indexAtDepth, p.Branching()); err != nil {
+		// Only positions moved beyond the bounds of the game, e.g. by attacking too deep, are invalid.
+		panic(err)
+	}
+	if p.Branching() == BinaryBranching {
+		return uint64(1)<<uint(p.depth) | uint64(p.indexAtDepth)
+	}
+	k := uint64(p.Branching())
+	// The levels above hold 1 + k + ... + k^(depth-1) positions.
+	width, _ := pow(k, p.depth)
+	return (width-1)/(k-1) + 1 + uint64(p.indexAtDepth)
+}
+
+// pow returns base^exp, and false if the result overflows.
+func pow(base uint64, exp int) (uint64, bool) {
+	out := uint64(1)
+	for i := 0; i < exp; i++ {
+		hi, lo := bits.Mul64(out, base)
+		if hi != 0 {
+			return 0, false
+		}
+		out = lo
+	}
+	return out, true
 }
 
 // MSBIndex returns the index of the most significant bit
@@ -1,6 +1,7 @@
 package fault
 
 import (
+	"math"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -95,3 +96,100 @@ func TestTraceIndex(t *testing.T) {
 		require.Equal(t, test.TraceIndex, result)
 	}
 }
+
+var ternaryTreeNodesMaxDepth2 = []testNodeInfo{
+	{GIndex: 1, Depth: 0, IndexAtDepth: 0, TraceIndex: 8},
+
+	{GIndex: 2, Depth: 1, IndexAtDepth: 0, TraceIndex: 2},
+	{GIndex: 3, Depth: 1, IndexAtDepth: 1, TraceIndex: 5},
+	{GIndex: 4, Depth: 1, IndexAtDepth: 2, TraceIndex: 8},
+
+	{GIndex: 5, Depth: 2, IndexAtDepth: 0, TraceIndex: 0},
+	{GIndex: 6, Depth: 2, IndexAtDepth: 1, TraceIndex: 1},
+	{GIndex: 7, Depth: 2, IndexAtDepth: 2, TraceIndex: 2},
+	{GIndex: 8, Depth: 2, IndexAtDepth: 3, TraceIndex: 3},
+	{GIndex: 9, Depth: 2, IndexAtDepth: 4, TraceIndex: 4},
+	{GIndex: 10, Depth: 2, IndexAtDepth: 5, TraceIndex: 5},
+	{GIndex: 11, Depth: 2, IndexAtDepth: 6, TraceIndex: 6},
+	{GIndex: 12, Depth: 2, IndexAtDepth: 7, TraceIndex: 7},
+	{GIndex: 13, Depth: 2, IndexAtDepth: 8, TraceIndex: 8},
+}
+
+// TestBranching_Ternary tests the generalized index and trace index conversions on the ternaryTreeNodesMaxDepth2 data
+func TestBranching_Ternary(t *testing.T) {
+	for _, test := range ternaryTreeNodesMaxDepth2 {
+		pos := NewPositionWithBranching(test.Depth, test.IndexAtDepth, 3)
+		require.Equal(t, pos, NewPositionWithBranchingFromGIndex(test.GIndex, 3))
+		require.Equal(t, test.GIndex, pos.ToGIndex())
+		require.Equal(t, test.TraceIndex, pos.TraceIndex(2))
+	}
+}
+
+// TestBranching_Moves tests attack, defend and child positions with a non binary branching factor.
+func TestBranching_Moves(t *testing.T) {
+	pos := NewPositionWithBranching(1, 1, 4)
+	require.Equal(t, 4, pos.Branching())
+	require.Equal(t, NewPositionWithBranching(2, 4, 4), pos.Attack())
+	require.Equal(t, NewPositionWithBranching(2, 6, 4), pos.Child(2))
+	require.Equal(t, NewPositionWithBranching(2, 12, 4), pos.Defend())
+}
+
+// TestBranching_Binary tests that binary positions are the same however they are created.
+func TestBranching_Binary(t *testing.T) {
+	for _, test := range treeNodesMaxDepth4 {
+		pos := NewPositionWithBranching(test.Depth, test.IndexAtDepth, BinaryBranching)
+		require.Equal(t, NewPosition(test.Depth, test.IndexAtDepth), pos)
+		require.Equal(t, pos, NewPositionWithBranchingFromGIndex(test.GIndex, BinaryBranching))
+		require.Equal(t, pos.Attack(), pos.Child(0))
+	}
+}
+
+// TestPosition_Invalid tests that positions outside of the game tree are rejected.
+func TestPosition_Invalid(t *testing.T) {
+	tests := []struct {
+		name                        string
+		depth, indexAtDepth, branch int
+	}{
+		{"BranchingZero", 1, 0, 0},
+		{"BranchingOne", 1, 0, 1},
+		{"NegativeDepth", -1, 0, 2},
+		{"NegativeIndex", 1, -1, 2},
+		{"IndexBeyondDepth", 2, 4, 2},
+		{"TernaryIndexBeyondDepth", 2, 9, 3},
+		{"BinaryTooDeep", 64, 0, 2},
+		{"TernaryTooDeep", 41, 0, 3},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			require.ErrorIs(t, checkPosition(test.depth, test.indexAtDepth, test.branch), ErrInvalidPosition)
+			require.Panics(t, func() { NewPositionWithBranching(test.depth, test.indexAtDepth, test.branch) })
+		})
+	}
+}
+
+// TestPosition_GIndexBounds tests the conversions at the limits of 64 bit generalized indices.
+func TestPosition_GIndexBounds(t *testing.T) {
+	require.Panics(t, func() { NewPositionFromGIndex(0) })
+	require.Panics(t, func() { NewPositionWithBranchingFromGIndex(0, 3) })
+	require.Panics(t, func() { NewPositionWithBranchingFromGIndex(5, 1) })
+
+	binary := NewPositionFromGIndex(math.MaxUint64)
+	require.Equal(t, NewPosition(63, math.MaxInt64), binary)
+	require.Equal(t, uint64(math.MaxUint64), binary.ToGIndex())
+
+	// The deepest ternary level that fits in 64 bits is depth 40. Its last position does not fit in an int,
+	// but the ones before it do and round trip.
+	for _, x := range []uint64{math.MaxUint64 / 2, math.MaxUint64 / 3} {
+		pos := NewPositionWithBranchingFromGIndex(x, 3)
+		require.Equal(t, x, pos.ToGIndex())
+	}
+	_, err := positionFromGIndex(math.MaxUint64, 3)
+	require.ErrorIs(t, err, ErrInvalidPosition)
+
+	_, ok := pow(3, 41)
+	require.False(t, ok)
+	width, ok := pow(3, 40)
+	require.True(t, ok)
+	require.Equal(t, uint64(12157665459056928801), width)
+}
@@ -0,0 +1,66 @@
+package fault
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/ethereum-optimism/optimism/op-node/eth"
+)
+
+// claimsMappingSlot is the storage slot of the claims mapping of the fault dispute game,
+// which records the hash of every claim that was added to the game.
+// It must be kept in sync with the contract storage layout.
+const claimsMappingSlot = 2
+
+var (
+	ErrProofWrongAccount = errors.New("proof is for another account")
+	ErrProofMissingSlot  = errors.New("proof does not include the claim storage slot")
+	ErrClaimNotIncluded  = errors.New("claim is not included in the game")
+)
+
+// ClaimHash returns the hash the contract records a claim under,
+// i.e. keccak256(abi.encodePacked(claim, position)).
+func ClaimHash(claim ClaimData) common.Hash {
+	position := common.BigToHash(new(big.Int).SetUint64(claim.ToGIndex()))
+	return crypto.Keccak256Hash(claim.Value[:], position[:])
+}
+
+// ClaimStorageSlot returns the storage slot of the game contract that is set
+// if and only if the claim was added to the game.
+func ClaimStorageSlot(claim ClaimData) common.Hash {
+	hash := ClaimHash(claim)
+	slot := common.BigToHash(big.NewInt(claimsMappingSlot))
+	return crypto.Keccak256Hash(hash[:], slot[:])
+}
+
+// VerifyClaimInclusion verifies that the proof shows the claim existed in the game at the state root.
+// The proof is the result of eth_getProof for the game address and the [ClaimStorageSlot] of the claim,
+// at the block of the state root.
+func VerifyClaimInclusion(stateRoot common.Hash, game common.Address, claim ClaimData, proof *eth.AccountResult) error {
+	if proof.Address != game {
+		return fmt.Errorf("%w: expected %v, got %v", ErrProofWrongAccount, game, proof.Address)
+	}
+	slot := ClaimStorageSlot(claim)
+	var entry *eth.StorageProofEntry
+	for i := range proof.StorageProof {
+		if proof.StorageProof[i].Key == slot {
+			entry = &proof.StorageProof[i]
+			break
+		}
+	}
+	if entry == nil {
+		return fmt.Errorf("%w: %v", ErrProofMissingSlot, slot)
+	}
+	// Unset slots read as zero, so a proof of a zero value shows the claim was never added.
+	if entry.Value.ToInt().Sign() == 0 {
+		return ErrClaimNotIncluded
+	}
+	if err := proof.Verify(stateRoot); err != nil {
+		return fmt.Errorf("invalid proof: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package fault
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/eth"
+)
+
+// proofList collects the nodes of a merkle proof.
+type proofList []hexutil.Bytes
+
+func (p *proofList) Put(key []byte, value []byte) error {
+	*p = append(*p, value)
+	return nil
+}
+
+func (p *proofList) Delete(key []byte) error {
+	panic("not supported")
+}
+
+// buildClaimProof builds a state with the game account whose storage includes the claims,
+// and returns its root and the proof for the storage slot of the proven claim.
+func buildClaimProof(t *testing.T, game common.Address, included []ClaimData, proven ClaimData) (common.Hash, *eth.AccountResult) {
+	db := trie.NewDatabase(rawdb.NewMemoryDatabase())
+	storage := trie.NewEmpty(db)
+	for _, claim := range included {
+		slot := ClaimStorageSlot(claim)
+		value, err := rlp.EncodeToBytes(big.NewInt(1).Bytes())
+		require.NoError(t, err)
+		require.NoError(t, storage.Update(crypto.Keccak256(slot[:]), value))
+	}
+	slot := ClaimStorageSlot(proven)
+	var storageProof proofList
+	require.NoError(t, storage.Prove(crypto.Keccak256(slot[:]), 0, &storageProof))
+	value := new(big.Int)
+	for _, claim := range included {
+		if claim == proven {
+			value.SetUint64(1)
+		}
+	}
+
+	result := &eth.AccountResult{
+		Address:     game,
+		Balance:     (*hexutil.Big)(big.NewInt(0)),
+		CodeHash:    common.Hash{0xc0},
+		Nonce:       1,
+		StorageHash: storage.Hash(),
+		StorageProof: []eth.StorageProofEntry{
+			{Key: slot, Value: hexutil.Big(*value), Proof: storageProof},
+		},
+	}
+	account, err := rlp.EncodeToBytes([]any{uint64(result.Nonce), result.Balance.ToInt().Bytes(), result.StorageHash, result.CodeHash})
+	require.NoError(t, err)
+	state := trie.NewEmpty(db)
+	require.NoError(t, state.Update(crypto.Keccak256(game[:]), account))
+	var accountProof proofList
+	require.NoError(t, state.Prove(crypto.Keccak256(game[:]), 0, &accountProof))
+	result.AccountProof = accountProof
+	return state.Hash(), result
+}
+
+// TestClaimHash tests that claims are hashed with their generalized index like the contract does.
+func TestClaimHash(t *testing.T) {
+	claim := ClaimData{Value: common.Hash{0x01}, Position: NewPosition(1, 1)}
+	position := common.Hash{}
+	position[31] = 3
+	require.Equal(t, crypto.Keccak256Hash(claim.Value[:], position[:]), ClaimHash(claim))
+}
+
+// TestVerifyClaimInclusion tests the verification of claim inclusion proofs.
+func TestVerifyClaimInclusion(t *testing.T) {
+	game := common.Address{0xaa}
+	root := ClaimData{Value: common.Hash{0x01}, Position: NewPosition(0, 0)}
+	attack := ClaimData{Value: common.Hash{0x02}, Position: NewPosition(1, 0)}
+	missing := ClaimData{Value: common.Hash{0x03}, Position: NewPosition(1, 0)}
+
+	t.Run("Included", func(t *testing.T) {
+		stateRoot, proof := buildClaimProof(t, game, []ClaimData{root, attack}, attack)
+		require.NoError(t, VerifyClaimInclusion(stateRoot, game, attack, proof))
+	})
+
+	t.Run("NotIncluded", func(t *testing.T) {
+		stateRoot, proof := buildClaimProof(t, game, []ClaimData{root, attack}, missing)
+		require.ErrorIs(t, VerifyClaimInclusion(stateRoot, game, missing, proof), ErrClaimNotIncluded)
+	})
+
+	t.Run("WrongAccount", func(t *testing.T) {
+		stateRoot, proof := buildClaimProof(t, game, []ClaimData{root, attack}, attack)
+		require.ErrorIs(t, VerifyClaimInclusion(stateRoot, common.Address{0xbb}, attack, proof), ErrProofWrongAccount)
+	})
+
+	t.Run("MissingSlot", func(t *testing.T) {
+		stateRoot, proof := buildClaimProof(t, game, []ClaimData{root, attack}, attack)
+		require.ErrorIs(t, VerifyClaimInclusion(stateRoot, game, root, proof), ErrProofMissingSlot)
+	})
+
+	t.Run("WrongStateRoot", func(t *testing.T) {
+		_, proof := buildClaimProof(t, game, []ClaimData{root, attack}, attack)
+		require.ErrorContains(t, VerifyClaimInclusion(common.Hash{0x01}, game, attack, proof), "invalid proof")
+	})
+}
@@ -0,0 +1,163 @@
+package fault
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// RenderFormat is a graph description language a claim tree can be rendered to.
+type RenderFormat string
+
+const (
+	RenderDOT     RenderFormat = "dot"
+	RenderMermaid RenderFormat = "mermaid"
+)
+
+// Render writes the claim tree formed by the claims as a graph in the given format.
+// Each claim is shown with its position, trace index, truncated value and, if its provenance is
+// known, its claimant, and countered claims are drawn dashed. The claims must be in contract order,
+// as returned by [Loader.FetchClaims], so that every parent comes before its children. If trace is not nil, claims the trace agrees with are highlighted
+// as honest and the others as dishonest.
+func Render(w io.Writer, format RenderFormat, claims []Claim, maxDepth int, trace TraceProvider) error {
+	nodes, err := renderNodes(claims, maxDepth, trace)
+	if err != nil {
+		return err
+	}
+	switch format {
+	case RenderDOT:
+		return renderDOT(w, nodes)
+	case RenderMermaid:
+		return renderMermaid(w, nodes)
+	default:
+		return fmt.Errorf("unknown render format %q", format)
+	}
+}
+
+// renderNode is a claim prepared for rendering.
+type renderNode struct {
+	id       string
+	parentID string
+	label    string
+	defends  bool
+	claim    Claim
+	// honest is nil if there is no trace to compare the claim against.
+	honest *bool
+}
+
+func renderNodes(claims []Claim, maxDepth int, trace TraceProvider) ([]renderNode, error) {
+	ids := make(map[int]string, len(claims))
+	nodes := make([]renderNode, 0, len(claims))
+	for i, claim := range claims {
+		id := fmt.Sprintf("c%d", claim.ContractIndex)
+		ids[claim.ContractIndex] = id
+		traceIndex := claim.TraceIndex(maxDepth)
+		label := fmt.Sprintf("d%d i%d (t%d)\\n%s", claim.Depth(), claim.IndexAtDepth(), traceIndex, truncateHash(claim.Value.Hex()))
+		if claimant := claim.Provenance.Claimant; claimant != (common.Address{}) {
+			label += "\\nby " + truncateHash(claimant.Hex())
+		}
+		node := renderNode{
+			id:    id,
+			claim: claim,
+			label: label,
+		}
+		if !claim.IsRoot() {
+			parentID, ok := ids[claim.ParentContractIndex]
+			if !ok {
+				return nil, fmt.Errorf("%w: parent %d of claim %d", ErrClaimNotFound, claim.ParentContractIndex, i)
+			}
+			node.parentID = parentID
+			node.defends = claim.DefendsParent()
+		}
+		if trace != nil {
+			expected, err := trace.Get(traceIndex)
+			if err != nil {
+				return nil, err
+			}
+			honest := expected == claim.Value
+			node.honest = &honest
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes, nil
+}
+
+// truncateHash keeps the start and end of a hex encoded hash or address.
+func truncateHash(hex string) string {
+	if len(hex) <= 14 {
+		return hex
+	}
+	return hex[:8] + ".." + hex[len(hex)-4:]
+}
+
+func edgeLabel(n renderNode) string {
+	if n.defends {
+		return "defend"
+	}
+	return "attack"
+}
+
+func renderDOT(w io.Writer, nodes []renderNode) error {
+	var b strings.Builder
+	b.WriteString("digraph game {\n")
+	b.WriteString("\tnode [shape=box, style=filled, fillcolor=white];\n")
+	for _, n := range nodes {
+		var attrs []string
+		attrs = append(attrs, fmt.Sprintf("label=\"%s\"", n.label))
+		if n.claim.Countered {
+			attrs = append(attrs, "style=\"filled,dashed\"")
+		}
+		if n.honest != nil {
+			color := "lightcoral"
+			if *n.honest {
+				color = "palegreen"
+			}
+			attrs = append(attrs, "fillcolor="+color)
+		}
+		fmt.Fprintf(&b, "\t%s [%s];\n", n.id, strings.Join(attrs, ", "))
+	}
+	for _, n := range nodes {
+		if n.parentID != "" {
+			fmt.Fprintf(&b, "\t%s -> %s [label=\"%s\"];\n", n.parentID, n.id, edgeLabel(n))
+		}
+	}
+	b.WriteString("}\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func renderMermaid(w io.Writer, nodes []renderNode) error {
+	var b strings.Builder
+	b.WriteString("graph TD\n")
+	b.WriteString("\tclassDef honest fill:#98fb98\n")
+	b.WriteString("\tclassDef dishonest fill:#f08080\n")
+	b.WriteString("\tclassDef countered stroke-dasharray:5 5\n")
+	for _, n := range nodes {
+		fmt.Fprintf(&b, "\t%s[\"%s\"]\n", n.id, strings.ReplaceAll(n.label, "\\n", "<br/>"))
+	}
+	for _, n := range nodes {
+		if n.parentID != "" {
+			fmt.Fprintf(&b, "\t%s -->|%s| %s\n", n.parentID, edgeLabel(n), n.id)
+		}
+	}
+	for _, n := range nodes {
+		var classes []string
+		if n.honest != nil {
+			if *n.honest {
+				classes = append(classes, "honest")
+			} else {
+				classes = append(classes, "dishonest")
+			}
+		}
+		if n.claim.Countered {
+			classes = append(classes, "countered")
+		}
+		for _, class := range classes {
+			fmt.Fprintf(&b, "\tclass %s %s\n", n.id, class)
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
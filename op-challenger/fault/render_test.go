@@ -0,0 +1,54 @@
+package fault
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func renderTestClaims(provider *AlphabetProvider) []Claim {
+	root := Claim{ClaimData: ClaimData{Value: common.Hash{0xff}, Position: NewPosition(0, 0)}, Countered: true}
+	attack := Claim{ClaimData: ClaimData{Value: provider.ComputeAlphabetClaim(3), Position: NewPosition(1, 0)}, Parent: root.ClaimData, ContractIndex: 1}
+	defend := Claim{ClaimData: ClaimData{Value: common.Hash{0xee}, Position: NewPosition(2, 2)}, Parent: attack.ClaimData, ContractIndex: 2, ParentContractIndex: 1}
+	defend.Provenance.Claimant = common.Address{0xab, 0xcd}
+	return []Claim{root, attack, defend}
+}
+
+// TestRender_DOT tests rendering a claim tree to GraphViz DOT.
+func TestRender_DOT(t *testing.T) {
+	provider := NewAlphabetProvider("abcdefgh", 3)
+	var out bytes.Buffer
+	require.NoError(t, Render(&out, RenderDOT, renderTestClaims(provider), 3, provider))
+
+	dot := out.String()
+	require.Contains(t, dot, "digraph game {")
+	require.Contains(t, dot, "c0 -> c1 [label=\"attack\"];")
+	require.Contains(t, dot, "c1 -> c2 [label=\"defend\"];")
+	require.Contains(t, dot, "c0 [label=\"d0 i0 (t7)\\n0xff0000..0000\", style=\"filled,dashed\", fillcolor=lightcoral];")
+	require.Contains(t, dot, "c1 [label=\"d1 i0 (t3)\\n0x000000..0364\", fillcolor=palegreen];")
+	require.Contains(t, dot, "c2 [label=\"d2 i2 (t5)\\n0xee0000..0000\\nby 0xABcD00..0000\", fillcolor=lightcoral];")
+}
+
+// TestRender_Mermaid tests rendering a claim tree to Mermaid.
+func TestRender_Mermaid(t *testing.T) {
+	provider := NewAlphabetProvider("abcdefgh", 3)
+	var out bytes.Buffer
+	require.NoError(t, Render(&out, RenderMermaid, renderTestClaims(provider), 3, nil))
+
+	mermaid := out.String()
+	require.Contains(t, mermaid, "graph TD\n")
+	require.Contains(t, mermaid, "\tc0 -->|attack| c1\n")
+	require.Contains(t, mermaid, "\tc0[\"d0 i0 (t7)<br/>0xff0000..0000\"]\n")
+	require.Contains(t, mermaid, "\tclass c0 countered\n")
+	require.NotContains(t, mermaid, "class c1 honest", "claims should not be highlighted without a trace")
+}
+
+// TestRender_MissingParent tests that claims must come after their parent.
+func TestRender_MissingParent(t *testing.T) {
+	claims := renderTestClaims(NewAlphabetProvider("abcdefgh", 3))
+	var out bytes.Buffer
+	err := Render(&out, RenderDOT, claims[1:], 3, nil)
+	require.ErrorIs(t, err, ErrClaimNotFound)
+}
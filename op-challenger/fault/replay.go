@@ -0,0 +1,35 @@
+package fault
+
+// ReplayAction is the action the solver would take against a claim of a replayed game.
+type ReplayAction struct {
+	Claim    Claim
+	Decision Decision
+	// Duplicate is true if the move already exists in the game.
+	Duplicate bool
+	// Err is the error returned by the solver, if any.
+	Err error
+}
+
+// Replay runs the solver against every claim of a game, e.g. as loaded at a historical block,
+// and returns the actions it would take, in the order of the claims.
+// Unlike an [Agent], it never responds, so it is safe to run against any game.
+func Replay(claims []Claim, solver *Solver) []ReplayAction {
+	existing := make(map[ClaimData]struct{}, len(claims))
+	for _, claim := range claims {
+		existing[claim.ClaimData] = struct{}{}
+	}
+	actions := make([]ReplayAction, 0, len(claims))
+	for _, claim := range claims {
+		decision, err := solver.Decide(claim)
+		action := ReplayAction{
+			Claim:    claim,
+			Decision: decision,
+			Err:      err,
+		}
+		if decision.Move != nil {
+			_, action.Duplicate = existing[decision.Move.ClaimData]
+		}
+		actions = append(actions, action)
+	}
+	return actions
+}
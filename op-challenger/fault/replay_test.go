@@ -0,0 +1,37 @@
+package fault
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+// TestReplay tests that the solver decisions are reported for every claim,
+// with moves that already exist flagged as duplicates.
+func TestReplay(t *testing.T) {
+	maxDepth := 3
+	provider := NewAlphabetProvider("abcdefgh", uint64(maxDepth))
+	solver := NewSolver(maxDepth, provider)
+
+	root := Claim{ClaimData: ClaimData{Value: common.Hash{0xff}, Position: NewPosition(0, 0)}}
+	attackPos := NewPosition(1, 0)
+	attack := Claim{
+		ClaimData:     ClaimData{Value: provider.ComputeAlphabetClaim(attackPos.TraceIndex(maxDepth)), Position: attackPos},
+		Parent:        root.ClaimData,
+		ContractIndex: 1,
+	}
+
+	actions := Replay([]Claim{root, attack}, solver)
+	require.Len(t, actions, 2)
+
+	require.Equal(t, root, actions[0].Claim)
+	require.Equal(t, ReasonRootDisagreed, actions[0].Decision.Reason)
+	require.True(t, actions[0].Duplicate, "the attack on the root claim was already made")
+
+	require.Equal(t, attack, actions[1].Claim)
+	require.Equal(t, ReasonClaimCountersParent, actions[1].Decision.Reason)
+	require.Nil(t, actions[1].Decision.Move)
+	require.False(t, actions[1].Duplicate)
+	require.NoError(t, actions[1].Err)
+}
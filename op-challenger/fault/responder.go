@@ -0,0 +1,100 @@
+package fault
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	challengerTypes "github.com/ethereum-optimism/optimism/op-challenger/types"
+	"github.com/ethereum-optimism/optimism/op-node/client"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+)
+
+// ErrMoveReverted is returned when a move transaction was included but reverted.
+var ErrMoveReverted = errors.New("move transaction reverted")
+
+// FaultResponder implements the [Responder] interface by sending moves
+// as transactions to a FaultDisputeGame contract.
+type FaultResponder struct {
+	log     log.Logger
+	txMgr   txmgr.TxManager
+	fdgAddr common.Address
+	fdgAbi  *abi.ABI
+}
+
+// NewFaultResponder returns a new [FaultResponder] for the game at fdgAddr.
+func NewFaultResponder(logger log.Logger, txManager txmgr.TxManager, fdgAddr common.Address) (*FaultResponder, error) {
+	fdgAbi, err := bindings.FaultDisputeGameMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return &FaultResponder{
+		log:     logger,
+		txMgr:   txManager,
+		fdgAddr: fdgAddr,
+		fdgAbi:  fdgAbi,
+	}, nil
+}
+
+// BuildTx builds the transaction data for the [Claim] response, which must
+// have its ParentContractIndex set.
+func (r *FaultResponder) BuildTx(ctx context.Context, response Claim) ([]byte, error) {
+	method := "attack"
+	if response.DefendsParent() {
+		method = "defend"
+	}
+	return r.fdgAbi.Pack(method, big.NewInt(int64(response.ParentContractIndex)), response.Value)
+}
+
+// Respond sends the [Claim] response to the game and waits for it to be included.
+// If the game rejects the move before it is sent, the returned error is a [challengerTypes.RevertError].
+func (r *FaultResponder) Respond(ctx context.Context, response Claim) error {
+	_, err := r.Send(ctx, response)
+	return err
+}
+
+// Send is like Respond, but also returns the receipt of the move transaction if it was included,
+// even if it reverted.
+func (r *FaultResponder) Send(ctx context.Context, response Claim) (receipt *types.Receipt, err error) {
+	ctx, span := client.StartSpan(ctx, spanResponderSend)
+	span.SetAttributes(gameAttribute(r.fdgAddr))
+	span.SetAttributes(claimAttributes(response)...)
+	defer func() {
+		if receipt != nil {
+			span.SetAttributes(attribute.String("tx.hash", receipt.TxHash.Hex()), attribute.Int64("tx.status", int64(receipt.Status)))
+			if receipt.BlockNumber != nil {
+				span.SetAttributes(attribute.Int64("tx.l1_block", receipt.BlockNumber.Int64()))
+			}
+		}
+		endSpan(span, err)
+	}()
+	txData, err := r.BuildTx(ctx, response)
+	if err != nil {
+		return nil, err
+	}
+	receipt, err = r.txMgr.Send(ctx, txmgr.TxCandidate{
+		To:     &r.fdgAddr,
+		TxData: txData,
+	})
+	if err != nil {
+		if revert := challengerTypes.DecodeRevert(err); revert != nil {
+			r.log.Warn("Move rejected by the game", "code", revert.Code, "benign", revert.Benign, "err", err)
+			return nil, revert
+		}
+		return nil, err
+	}
+	if receipt.Status == types.ReceiptStatusFailed {
+		r.log.Error("Move transaction reverted", "tx_hash", receipt.TxHash)
+		return receipt, fmt.Errorf("%w: %v", ErrMoveReverted, receipt.TxHash)
+	}
+	r.log.Info("Move transaction included", "tx_hash", receipt.TxHash, "block", receipt.BlockNumber)
+	return receipt, nil
+}
@@ -0,0 +1,114 @@
+package fault
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	challengerTypes "github.com/ethereum-optimism/optimism/op-challenger/types"
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+)
+
+var mockSendError = errors.New("mock send error")
+
+// mockRevertError is an RPC error carrying revert data.
+type mockRevertError struct {
+	data string
+}
+
+func (e mockRevertError) Error() string          { return "execution reverted" }
+func (e mockRevertError) ErrorData() interface{} { return e.data }
+
+type mockTxManager struct {
+	from    common.Address
+	sent    []txmgr.TxCandidate
+	status  uint64
+	sendErr error
+}
+
+func (m *mockTxManager) Send(ctx context.Context, candidate txmgr.TxCandidate) (*types.Receipt, error) {
+	if m.sendErr != nil {
+		return nil, m.sendErr
+	}
+	m.sent = append(m.sent, candidate)
+	return &types.Receipt{Status: m.status, BlockNumber: big.NewInt(1)}, nil
+}
+
+func (m *mockTxManager) From() common.Address {
+	return m.from
+}
+
+func newTestFaultResponder(t *testing.T, txMgr *mockTxManager) *FaultResponder {
+	responder, err := NewFaultResponder(testlog.Logger(t, log.LvlError), txMgr, common.Address{0xfd})
+	require.NoError(t, err)
+	return responder
+}
+
+// TestFaultResponder_BuildTx tests that attacks and defenses are encoded for the right method.
+func TestFaultResponder_BuildTx(t *testing.T) {
+	responder := newTestFaultResponder(t, &mockTxManager{})
+	fdgAbi, err := bindings.FaultDisputeGameMetaData.GetAbi()
+	require.NoError(t, err)
+
+	parent := NewPosition(1, 0)
+	attack := Claim{ClaimData: ClaimData{Value: common.Hash{0x01}, Position: parent.Attack()}, Parent: ClaimData{Position: parent}, ParentContractIndex: 1}
+	defend := Claim{ClaimData: ClaimData{Value: common.Hash{0x02}, Position: parent.Defend()}, Parent: ClaimData{Position: parent}, ParentContractIndex: 1}
+
+	txData, err := responder.BuildTx(context.Background(), attack)
+	require.NoError(t, err)
+	expected, err := fdgAbi.Pack("attack", big.NewInt(1), common.Hash{0x01})
+	require.NoError(t, err)
+	require.Equal(t, expected, txData)
+
+	txData, err = responder.BuildTx(context.Background(), defend)
+	require.NoError(t, err)
+	expected, err = fdgAbi.Pack("defend", big.NewInt(1), common.Hash{0x02})
+	require.NoError(t, err)
+	require.Equal(t, expected, txData)
+}
+
+// TestFaultResponder_Respond tests sending responses to the game.
+func TestFaultResponder_Respond(t *testing.T) {
+	root := NewPosition(0, 0)
+	response := Claim{ClaimData: ClaimData{Value: common.Hash{0x01}, Position: root.Attack()}, Parent: ClaimData{Position: root}}
+
+	t.Run("Succeeds", func(t *testing.T) {
+		txMgr := &mockTxManager{status: types.ReceiptStatusSuccessful}
+		require.NoError(t, newTestFaultResponder(t, txMgr).Respond(context.Background(), response))
+		require.Len(t, txMgr.sent, 1)
+		require.Equal(t, common.Address{0xfd}, *txMgr.sent[0].To)
+	})
+
+	t.Run("Reverted", func(t *testing.T) {
+		txMgr := &mockTxManager{status: types.ReceiptStatusFailed}
+		err := newTestFaultResponder(t, txMgr).Respond(context.Background(), response)
+		require.ErrorIs(t, err, ErrMoveReverted)
+	})
+
+	t.Run("SendFails", func(t *testing.T) {
+		txMgr := &mockTxManager{sendErr: mockSendError}
+		err := newTestFaultResponder(t, txMgr).Respond(context.Background(), response)
+		require.ErrorIs(t, err, mockSendError)
+	})
+
+	t.Run("GameReverts", func(t *testing.T) {
+		selector := crypto.Keccak256([]byte("ClaimAlreadyExists()"))[:4]
+		txMgr := &mockTxManager{sendErr: fmt.Errorf("failed to estimate gas: %w", mockRevertError{data: hexutil.Encode(selector)})}
+		err := newTestFaultResponder(t, txMgr).Respond(context.Background(), response)
+		require.ErrorIs(t, err, challengerTypes.ErrClaimAlreadyExists)
+		var revert *challengerTypes.RevertError
+		require.ErrorAs(t, err, &revert)
+		require.True(t, revert.Benign)
+	})
+}
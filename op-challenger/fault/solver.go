@@ -6,6 +6,14 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 )
 
+var (
+	// ErrGameDepthReached is returned when moving against a claim at the maximum game depth.
+	ErrGameDepthReached = errors.New("game depth reached")
+
+	// ErrCannotDefendRoot is returned when defending the root claim, which has no parent to defend against.
+	ErrCannotDefendRoot = errors.New("cannot defend the root claim")
+)
+
 // Solver uses a [TraceProvider] to determine the moves to make in a dispute game.
 type Solver struct {
 	TraceProvider
@@ -21,53 +29,107 @@ func NewSolver(gameDepth int, traceProvider TraceProvider) *Solver {
 	}
 }
 
+// Reason is a stable code explaining a decision of the [Solver], suitable for log aggregation.
+type Reason string
+
+const (
+	// ReasonRootAgreed means the root claim is correct, so it is left alone.
+	ReasonRootAgreed Reason = "root_agreed"
+	// ReasonRootDisagreed means the root claim is incorrect, so it is attacked.
+	ReasonRootDisagreed Reason = "root_disagreed"
+	// ReasonMaxDepth means the claim is at the maximum game depth and cannot be moved against.
+	ReasonMaxDepth Reason = "max_depth"
+	// ReasonClaimAgreed means both the claim and its parent are correct,
+	// so the difference must be to the right of the claim and it is defended.
+	ReasonClaimAgreed Reason = "claim_agreed"
+	// ReasonClaimDisagreed means the parent is correct but the claim is not,
+	// so the difference must be to the left of the claim and it is attacked.
+	ReasonClaimDisagreed Reason = "claim_disagreed"
+	// ReasonClaimCountersParent means the claim correctly counters an incorrect parent, so it is left alone.
+	ReasonClaimCountersParent Reason = "claim_counters_parent"
+	// ReasonClaimAndParentDisagreed means both the claim and its parent are incorrect, so the claim is attacked.
+	// The counter to the parent is created when the parent itself is evaluated.
+	ReasonClaimAndParentDisagreed Reason = "claim_and_parent_disagreed"
+)
+
+// Decision is the outcome of evaluating a claim.
+type Decision struct {
+	// Move is the response to make, or nil if the claim should be left alone.
+	Move *Claim `json:"move"`
+	// Reason explains why the move was chosen.
+	Reason Reason `json:"reason"`
+}
+
 // NextMove returns the next move to make given the current state of the game.
 func (s *Solver) NextMove(claim Claim) (*Claim, error) {
+	decision, err := s.Decide(claim)
+	return decision.Move, err
+}
+
+// Decide determines the next move to make against the claim and the reason for it.
+// The reason is set even if an error is returned while building the move.
+func (s *Solver) Decide(claim Claim) (Decision, error) {
 	// Special case of the root claim
 	if claim.IsRoot() {
 		agree, err := s.agreeWithClaim(claim.ClaimData)
 		if err != nil {
-			return nil, err
+			return Decision{}, err
 		}
 		// Attack the root claim if we do not agree with it
 		if !agree {
-			return s.attack(claim)
-		} else {
-			return nil, nil
+			move, err := s.attack(claim)
+			return Decision{Move: move, Reason: ReasonRootDisagreed}, err
 		}
-
+		return Decision{Reason: ReasonRootAgreed}, nil
 	}
 
 	parentCorrect, err := s.agreeWithClaim(claim.Parent)
 	if err != nil {
-		return nil, err
+		return Decision{}, err
 	}
 	claimCorrect, err := s.agreeWithClaim(claim.ClaimData)
 	if err != nil {
-		return nil, err
+		return Decision{}, err
 	}
 	if claim.Depth() == s.gameDepth {
-		return nil, errors.New("game depth reached")
+		return Decision{Reason: ReasonMaxDepth}, ErrGameDepthReached
 	}
 	if parentCorrect && claimCorrect {
 		// We agree with the parent, but the claim is disagreeing with it.
 		// Since we agree with the claim, the difference must be to the right of the claim
-		return s.defend(claim)
+		move, err := s.defend(claim)
+		return Decision{Move: move, Reason: ReasonClaimAgreed}, err
 	} else if parentCorrect && !claimCorrect {
 		// We agree with the parent, but the claim disagrees with it.
 		// Since we disagree with the claim, the difference must be to the left of the claim
-		return s.attack(claim)
+		move, err := s.attack(claim)
+		return Decision{Move: move, Reason: ReasonClaimDisagreed}, err
 	} else if !parentCorrect && claimCorrect {
 		// Do nothing, we disagree with the parent, but this claim has correctly countered it
-		return nil, nil
-	} else if !parentCorrect && !claimCorrect {
+		return Decision{Reason: ReasonClaimCountersParent}, nil
+	} else {
 		// We disagree with the parent so want to counter it (which the claim is doing)
 		// but we also disagree with the claim so there must be a difference to the left of claim
 		// Note that we will create the correct counter-claim for parent when it is evaluated, no need to do it here
+		move, err := s.attack(claim)
+		return Decision{Move: move, Reason: ReasonClaimAndParentDisagreed}, err
+	}
+}
+
+// Counter returns the response to the claim at its attack position, or its defend position if
+// attack is false, with the value taken from the trace. Unlike NextMove, it does not decide whether
+// the move should be made at all, which lets an operator choose the move manually.
+func (s *Solver) Counter(claim Claim, attack bool) (*Claim, error) {
+	if claim.Depth() >= s.gameDepth {
+		return nil, ErrGameDepthReached
+	}
+	if attack {
 		return s.attack(claim)
 	}
-	// This should not be reached
-	return nil, errors.New("no next move")
+	if claim.IsRoot() {
+		return nil, ErrCannotDefendRoot
+	}
+	return s.defend(claim)
 }
 
 // attack returns a response that attacks the claim.
@@ -78,8 +140,9 @@ func (s *Solver) attack(claim Claim) (*Claim, error) {
 		return nil, err
 	}
 	return &Claim{
-		ClaimData: ClaimData{Value: value, Position: position},
-		Parent:    claim.ClaimData,
+		ClaimData:           ClaimData{Value: value, Position: position},
+		Parent:              claim.ClaimData,
+		ParentContractIndex: claim.ContractIndex,
 	}, nil
 }
 
@@ -91,8 +154,9 @@ func (s *Solver) defend(claim Claim) (*Claim, error) {
 		return nil, err
 	}
 	return &Claim{
-		ClaimData: ClaimData{Value: value, Position: position},
-		Parent:    claim.ClaimData,
+		ClaimData:           ClaimData{Value: value, Position: position},
+		Parent:              claim.ClaimData,
+		ParentContractIndex: claim.ContractIndex,
 	}, nil
 }
 
@@ -78,3 +78,160 @@ func TestSolver_NextMove_Opponent(t *testing.T) {
 		require.Equal(t, test.response, res.ClaimData)
 	}
 }
+
+// TestSolver_Counter tests that the [Solver] builds the requested
+// counter claim regardless of whether it agrees with the claim.
+func TestSolver_Counter(t *testing.T) {
+	maxDepth := 3
+	provider := NewAlphabetProvider("abcdefgh", uint64(maxDepth))
+	solver := NewSolver(maxDepth, provider)
+
+	root := Claim{ClaimData: ClaimData{Value: provider.ComputeAlphabetClaim(7), Position: NewPosition(0, 0)}}
+	attack, err := solver.Counter(root, true)
+	require.NoError(t, err)
+	require.Equal(t, NewPosition(1, 0), attack.Position)
+	require.Equal(t, provider.ComputeAlphabetClaim(3), attack.Value)
+	require.Equal(t, root.ClaimData, attack.Parent)
+
+	_, err = solver.Counter(root, false)
+	require.ErrorIs(t, err, ErrCannotDefendRoot)
+
+	claim := *attack
+	claim.ContractIndex = 1
+	defend, err := solver.Counter(claim, false)
+	require.NoError(t, err)
+	require.Equal(t, NewPosition(2, 2), defend.Position)
+	require.Equal(t, provider.ComputeAlphabetClaim(5), defend.Value)
+	require.Equal(t, 1, defend.ParentContractIndex)
+
+	bottom := Claim{ClaimData: ClaimData{Position: NewPosition(maxDepth, 0)}}
+	_, err = solver.Counter(bottom, true)
+	require.ErrorIs(t, err, ErrGameDepthReached)
+}
+
+// TestSolver_Decide_Reasons tests the reason codes of the [Solver] decisions.
+func TestSolver_Decide_Reasons(t *testing.T) {
+	maxDepth := 3
+	provider := NewAlphabetProvider("abcdefgh", uint64(maxDepth))
+	solver := NewSolver(maxDepth, provider)
+	correct := func(pos Position) ClaimData {
+		return ClaimData{Value: provider.ComputeAlphabetClaim(pos.TraceIndex(maxDepth)), Position: pos}
+	}
+	incorrect := func(pos Position) ClaimData {
+		return ClaimData{Value: common.Hash{0xff}, Position: pos}
+	}
+	root := NewPosition(0, 0)
+	child := NewPosition(1, 0)
+
+	tests := []struct {
+		name   string
+		claim  Claim
+		reason Reason
+		moves  bool
+	}{
+		{"RootAgreed", Claim{ClaimData: correct(root)}, ReasonRootAgreed, false},
+		{"RootDisagreed", Claim{ClaimData: incorrect(root)}, ReasonRootDisagreed, true},
+		{"ClaimAgreed", Claim{ClaimData: correct(child), Parent: correct(root)}, ReasonClaimAgreed, true},
+		{"ClaimDisagreed", Claim{ClaimData: incorrect(child), Parent: correct(root)}, ReasonClaimDisagreed, true},
+		{"ClaimCountersParent", Claim{ClaimData: correct(child), Parent: incorrect(root)}, ReasonClaimCountersParent, false},
+		{"ClaimAndParentDisagreed", Claim{ClaimData: incorrect(child), Parent: incorrect(root)}, ReasonClaimAndParentDisagreed, true},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			decision, err := solver.Decide(test.claim)
+			require.NoError(t, err)
+			require.Equal(t, test.reason, decision.Reason)
+			require.Equal(t, test.moves, decision.Move != nil)
+		})
+	}
+
+	decision, err := solver.Decide(Claim{ClaimData: correct(NewPosition(3, 0)), Parent: correct(NewPosition(2, 0))})
+	require.ErrorIs(t, err, ErrGameDepthReached)
+	require.Equal(t, ReasonMaxDepth, decision.Reason)
+}
+
+// buildFuzzClaims decodes the fuzz input into a claim tree. Every two bytes are an instruction:
+// the first selects the claim to respond to, and the second whether to attack or defend it
+// and whether to use the honest or the dishonest trace for the value.
+// Instructions that would produce an invalid move are skipped.
+func buildFuzzClaims(program []byte, maxDepth int, honest, dishonest TraceProvider) ([]Claim, error) {
+	rootPos := NewPosition(0, 0)
+	rootValue, err := dishonest.Get(rootPos.TraceIndex(maxDepth))
+	if err != nil {
+		return nil, err
+	}
+	claims := []Claim{{ClaimData: ClaimData{Value: rootValue, Position: rootPos}}}
+	existing := map[ClaimData]bool{claims[0].ClaimData: true}
+	for i := 0; i+1 < len(program); i += 2 {
+		parentIdx := int(program[i]) % len(claims)
+		parent := claims[parentIdx]
+		attack := program[i+1]&1 == 0
+		if parent.Depth() >= maxDepth || (!attack && parent.IsRoot()) {
+			continue
+		}
+		pos := parent.Attack()
+		if !attack {
+			pos = parent.Defend()
+		}
+		trace := honest
+		if program[i+1]&2 != 0 {
+			trace = dishonest
+		}
+		value, err := trace.Get(pos.TraceIndex(maxDepth))
+		if err != nil {
+			return nil, err
+		}
+		claim := Claim{
+			ClaimData:           ClaimData{Value: value, Position: pos},
+			Parent:              parent.ClaimData,
+			ContractIndex:       len(claims),
+			ParentContractIndex: parentIdx,
+		}
+		if existing[claim.ClaimData] {
+			continue
+		}
+		existing[claim.ClaimData] = true
+		claims = append(claims, claim)
+	}
+	return claims, nil
+}
+
+// FuzzSolver_ClaimTree checks invariants of the [Solver] decisions over arbitrary claim trees.
+// Failing inputs are minimized by the fuzzing engine to the shortest sequence of adversary moves.
+func FuzzSolver_ClaimTree(f *testing.F) {
+	f.Add([]byte{})
+	f.Add([]byte{0, 0, 1, 1, 2, 2})
+	f.Add([]byte{0, 2, 1, 3, 2, 0, 3, 1})
+	maxDepth := 4
+	honest := NewAlphabetProvider("abcdefghijklmnop", uint64(maxDepth))
+	dishonest := NewAlphabetProvider("abcdefghijkxyzzz", uint64(maxDepth))
+	solver := NewSolver(maxDepth, honest)
+
+	f.Fuzz(func(t *testing.T, program []byte) {
+		claims, err := buildFuzzClaims(program, maxDepth, honest, dishonest)
+		require.NoError(t, err)
+		for _, claim := range claims {
+			decision, err := solver.Decide(claim)
+			if claim.Depth() == maxDepth {
+				require.ErrorIs(t, err, ErrGameDepthReached)
+				continue
+			}
+			require.NoError(t, err)
+			if decision.Move == nil {
+				require.Contains(t, []Reason{ReasonRootAgreed, ReasonClaimCountersParent}, decision.Reason)
+				continue
+			}
+			move := decision.Move
+			require.Equal(t, claim.ClaimData, move.Parent, "moves must respond to the evaluated claim")
+			require.Equal(t, claim.ContractIndex, move.ParentContractIndex)
+			require.Equal(t, claim.Depth()+1, move.Depth())
+			expected, err := honest.Get(move.TraceIndex(maxDepth))
+			require.NoError(t, err)
+			require.Equal(t, expected, move.Value, "the solver must only make honest claims")
+			if move.DefendsParent() {
+				require.Equal(t, ReasonClaimAgreed, decision.Reason, "only claims the solver agrees with may be defended")
+			}
+		}
+	})
+}
@@ -0,0 +1,44 @@
+package fault
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+// Names of the OpenTelemetry spans of the game-play pipeline. A game is loaded and updated by a
+// [GameTracker], and every tick of an [Agent] decides on a move per claim and sends it through
+// the [FaultResponder], which waits for the move transaction to be confirmed.
+// The spans are started with client.StartSpan, so the RPC requests made on their behalf are child spans.
+const (
+	spanGameLoad        = "game.load"
+	spanGameApplyLogs   = "game.apply_logs"
+	spanGameCheckReorgs = "game.check_reorgs"
+	spanAgentTick       = "agent.tick"
+	spanAgentMove       = "agent.move"
+	spanResponderSend   = "responder.send"
+)
+
+func gameAttribute(game common.Address) attribute.KeyValue {
+	return attribute.String("game.address", game.Hex())
+}
+
+// claimAttributes identifies the claim by its contract index and position.
+func claimAttributes(claim Claim) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.Int("claim.index", claim.ContractIndex),
+		attribute.Int("claim.parent_index", claim.ParentContractIndex),
+		attribute.Int("claim.depth", claim.Depth()),
+		attribute.Int("claim.index_at_depth", claim.IndexAtDepth()),
+	}
+}
+
+// endSpan ends the span, marking it as failed if err is not nil.
+func endSpan(span oteltrace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
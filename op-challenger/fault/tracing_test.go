@@ -0,0 +1,127 @@
+package fault
+
+import (
+	"context"
+	"math"
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	oteltrace "go.opentelemetry.io/otel/trace"
+
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+)
+
+// recordingTracerProvider is an OpenTelemetry tracer provider that keeps the spans started by its tracers.
+type recordingTracerProvider struct {
+	mu    sync.Mutex
+	spans []*recordingSpan
+}
+
+func (p *recordingTracerProvider) Tracer(string, ...oteltrace.TracerOption) oteltrace.Tracer {
+	return p
+}
+
+func (p *recordingTracerProvider) Start(ctx context.Context, name string, _ ...oteltrace.SpanStartOption) (context.Context, oteltrace.Span) {
+	span := &recordingSpan{Span: oteltrace.SpanFromContext(ctx), name: name, attrs: make(map[attribute.Key]attribute.Value)}
+	if parent, ok := oteltrace.SpanFromContext(ctx).(*recordingSpan); ok {
+		span.parent = parent.name
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.spans = append(p.spans, span)
+	return oteltrace.ContextWithSpan(ctx, span), span
+}
+
+// named returns the spans with the given name, in the order they were started.
+func (p *recordingTracerProvider) named(name string) []*recordingSpan {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var spans []*recordingSpan
+	for _, span := range p.spans {
+		if span.name == name {
+			spans = append(spans, span)
+		}
+	}
+	return spans
+}
+
+type recordingSpan struct {
+	oteltrace.Span
+	name   string
+	parent string
+	attrs  map[attribute.Key]attribute.Value
+	ended  bool
+}
+
+func (s *recordingSpan) SetAttributes(kv ...attribute.KeyValue) {
+	for _, attr := range kv {
+		s.attrs[attr.Key] = attr.Value
+	}
+}
+func (s *recordingSpan) End(...oteltrace.SpanEndOption) { s.ended = true }
+
+func recordSpans(t *testing.T) *recordingTracerProvider {
+	provider := new(recordingTracerProvider)
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+	return provider
+}
+
+// TestPipelineSpans tests that loading a game, deciding on a move and sending it create spans
+// identifying the game and the claims.
+func TestPipelineSpans(t *testing.T) {
+	spans := recordSpans(t)
+	game := common.Address{0xfd}
+	root := NewPosition(0, 0)
+	fetcher := &mockClaimFetcher{
+		claims: []mockClaimData{
+			{ParentIndex: math.MaxUint32, Claim: common.Hash{0xff}, Position: new(big.Int).SetUint64(root.ToGIndex())},
+		},
+	}
+	headers := &mockHeaderFetcher{latest: &types.Header{Number: big.NewInt(5)}}
+	tracker := NewGameTracker(testlog.Logger(t, log.LvlError), game, 3, NewLoader(fetcher), nil, headers)
+	require.NoError(t, tracker.Load(context.Background()))
+	load := spans.named(spanGameLoad)
+	require.Len(t, load, 1)
+	require.Equal(t, game.Hex(), load[0].attrs["game.address"].AsString())
+	require.Equal(t, int64(1), load[0].attrs["game.claims"].AsInt64())
+	require.Equal(t, int64(5), load[0].attrs["game.l1_block"].AsInt64())
+	require.True(t, load[0].ended)
+
+	txMgr := &mockTxManager{status: types.ReceiptStatusSuccessful}
+	maxDepth := 3
+	agent := NewAgent(tracker.Game(), game, maxDepth, NewAlphabetProvider("abcdefgh", uint64(maxDepth)), newTestFaultResponder(t, txMgr),
+		testlog.Logger(t, log.LvlError), &mockAgentMetrics{decisions: make(map[string]int)})
+	agent.PerformActions()
+
+	tick := spans.named(spanAgentTick)
+	require.Len(t, tick, 1)
+	require.Equal(t, game.Hex(), tick[0].attrs["game.address"].AsString())
+	require.Equal(t, int64(1), tick[0].attrs["game.claims"].AsInt64())
+
+	move := spans.named(spanAgentMove)
+	require.Len(t, move, 1)
+	require.Equal(t, spanAgentTick, move[0].parent)
+	require.Equal(t, int64(0), move[0].attrs["claim.index"].AsInt64())
+	require.Equal(t, decisionAttack, move[0].attrs["decision"].AsString())
+	require.Equal(t, string(ReasonRootDisagreed), move[0].attrs["decision.reason"].AsString())
+
+	send := spans.named(spanResponderSend)
+	require.Len(t, send, 1)
+	require.Equal(t, spanAgentMove, send[0].parent)
+	require.Equal(t, game.Hex(), send[0].attrs["game.address"].AsString())
+	require.Equal(t, int64(1), send[0].attrs["claim.depth"].AsInt64())
+	require.Equal(t, int64(types.ReceiptStatusSuccessful), send[0].attrs["tx.status"].AsInt64())
+	require.Contains(t, send[0].attrs, attribute.Key("tx.hash"))
+	for _, span := range []*recordingSpan{tick[0], move[0], send[0]} {
+		require.True(t, span.ended, span.name)
+	}
+}
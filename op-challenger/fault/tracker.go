@@ -0,0 +1,150 @@
+package fault
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/ethereum-optimism/optimism/op-node/client"
+)
+
+// GameTracker keeps the claims of a game in sync with the contract. All claims are loaded once,
+// after which the Move events of the game are applied with [Loader.ApplyEvents], so that only the
+// new claims are read. The game is reloaded in full if the events do not match the contract.
+// The claims are checked with [ValidateClaims] whenever they are loaded or updated.
+type GameTracker struct {
+	log      log.Logger
+	addr     common.Address
+	maxDepth int
+	loader   *Loader
+	filterer MoveFilterer
+	headers  HeaderFetcher
+
+	game Game
+	// block is the L1 block the game is up to date with.
+	block uint64
+}
+
+// NewGameTracker creates a new [GameTracker] for the game at addr with the given max depth. The game is loaded on the first call to [GameTracker.Load]
+// or [GameTracker.ApplyLogs]. The provenance of loaded claims is taken from the Move logs of the filterer.
+// If filterer is nil, only claims added by applied events have a provenance.
+func NewGameTracker(log log.Logger, addr common.Address, maxDepth int, loader *Loader, filterer MoveFilterer, headers HeaderFetcher) *GameTracker {
+	return &GameTracker{
+		log:      log,
+		addr:     addr,
+		maxDepth: maxDepth,
+		loader:   loader,
+		filterer: filterer,
+		headers:  headers,
+	}
+}
+
+// Game returns the tracked game, or nil if it was not loaded yet.
+func (t *GameTracker) Game() Game {
+	return t.game
+}
+
+// Load reloads all claims of the game from the contract at the latest L1 block.
+func (t *GameTracker) Load(ctx context.Context) (err error) {
+	ctx, span := client.StartSpan(ctx, spanGameLoad)
+	span.SetAttributes(gameAttribute(t.addr))
+	defer func() { endSpan(span, err) }()
+	header, err := t.headers.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to fetch latest L1 block: %w", err)
+	}
+	opts := &bind.CallOpts{Context: ctx, BlockNumber: header.Number}
+	var claims []Claim
+	if t.filterer != nil {
+		claims, err = t.loader.FetchAnnotatedClaims(opts, t.filterer)
+	} else {
+		claims, err = t.loader.FetchClaimsWithOpts(opts)
+	}
+	if err != nil {
+		return err
+	}
+	if err := ValidateClaims(claims, t.maxDepth); err != nil {
+		return err
+	}
+	game, err := gameFromClaims(claims)
+	if err != nil {
+		return err
+	}
+	t.game = game
+	t.block = header.Number.Uint64()
+	span.SetAttributes(attribute.Int("game.claims", len(claims)), attribute.Int64("game.l1_block", int64(t.block)))
+	t.log.Debug("Loaded game", "claims", len(claims), "l1_block", t.block)
+	return nil
+}
+
+// ApplyLogs updates the game from new logs of the game contract, in the order they were emitted.
+// Logs of blocks the game is already up to date with are skipped, unless they were removed by a reorg.
+// If the logs do not match the contract, or a Move log was removed, the game is reloaded instead.
+func (t *GameTracker) ApplyLogs(ctx context.Context, logs []types.Log) (err error) {
+	if t.game == nil {
+		return t.Load(ctx)
+	}
+	ctx, span := client.StartSpan(ctx, spanGameApplyLogs)
+	span.SetAttributes(gameAttribute(t.addr), attribute.Int("game.logs", len(logs)))
+	defer func() { endSpan(span, err) }()
+	var pending []types.Log
+	block := t.block
+	for _, log := range logs {
+		if log.Removed || log.BlockNumber > t.block {
+			pending = append(pending, log)
+		}
+		if log.BlockNumber > block {
+			block = log.BlockNumber
+		}
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+	game, err := t.loader.ApplyEvents(ctx, t.game, pending)
+	if errors.Is(err, ErrEventMismatch) || errors.Is(err, ErrLogRemoved) {
+		t.log.Warn("Game is out of sync with the contract, reloading", "err", err)
+		return t.Load(ctx)
+	} else if err != nil {
+		return err
+	}
+	claims := game.Claims()
+	sort.Slice(claims, func(i, j int) bool { return claims[i].ContractIndex < claims[j].ContractIndex })
+	if err := ValidateClaims(claims, t.maxDepth); err != nil {
+		return err
+	}
+	span.SetAttributes(attribute.Int("game.claims", len(claims)), attribute.Int64("game.l1_block", int64(block)))
+	t.game = game
+	t.block = block
+	return nil
+}
+
+// CheckReorgs reloads the game if the Move event of any of its claims is in a block that is
+// no longer canonical, since the game state built from such claims is stale.
+// It returns true if the game was reloaded.
+func (t *GameTracker) CheckReorgs(ctx context.Context) (reloaded bool, err error) {
+	if t.game == nil {
+		return false, nil
+	}
+	ctx, span := client.StartSpan(ctx, spanGameCheckReorgs)
+	span.SetAttributes(gameAttribute(t.addr))
+	defer func() {
+		span.SetAttributes(attribute.Bool("game.reloaded", reloaded))
+		endSpan(span, err)
+	}()
+	reorged, err := ReorgedClaims(ctx, t.headers, t.game.Claims())
+	if err != nil {
+		return false, err
+	}
+	if len(reorged) == 0 {
+		return false, nil
+	}
+	t.log.Warn("Claims were reorged out, reloading game", "reorged", len(reorged), "l1_block", reorged[0].Provenance.L1Block)
+	return true, t.Load(ctx)
+}
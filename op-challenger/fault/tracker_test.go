@@ -0,0 +1,133 @@
+package fault
+
+import (
+	"context"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+)
+
+func newTestTracker(t *testing.T, fetcher *mockClaimFetcher) *GameTracker {
+	headers := &mockHeaderFetcher{latest: &types.Header{Number: big.NewInt(5)}}
+	return NewGameTracker(testlog.Logger(t, log.LvlError), common.Address{0xfd}, 3, NewLoader(fetcher), nil, headers)
+}
+
+// TestGameTracker_ApplyLogs tests that new Move events are applied to the loaded game.
+func TestGameTracker_ApplyLogs(t *testing.T) {
+	root := NewPosition(0, 0)
+	attack := root.Attack()
+	fetcher := &mockClaimFetcher{
+		claims: []mockClaimData{
+			{ParentIndex: math.MaxUint32, Claim: common.Hash{0x01}, Position: new(big.Int).SetUint64(root.ToGIndex())},
+		},
+	}
+	tracker := newTestTracker(t, fetcher)
+	require.Nil(t, tracker.Game())
+	require.NoError(t, tracker.ApplyLogs(context.Background(), nil))
+	require.Len(t, tracker.Game().Claims(), 1)
+
+	// Logs of blocks the game was loaded at are already included.
+	old := moveLog(t, 0, common.Hash{0x02})
+	old.BlockNumber = 5
+	require.NoError(t, tracker.ApplyLogs(context.Background(), []types.Log{old}))
+	require.Len(t, tracker.Game().Claims(), 1)
+
+	fetcher.claims = append(fetcher.claims, mockClaimData{ParentIndex: 0, Claim: common.Hash{0x02}, Position: new(big.Int).SetUint64(attack.ToGIndex())})
+	require.NoError(t, tracker.ApplyLogs(context.Background(), []types.Log{moveLog(t, 0, common.Hash{0x02})}))
+	claims := tracker.Game().Claims()
+	require.Len(t, claims, 2)
+	require.True(t, claims[0].Countered)
+	require.Equal(t, uint64(7), claims[1].Provenance.L1Block)
+}
+
+// TestGameTracker_ReloadsOnMismatch tests that the game is reloaded when the events do not match the contract.
+func TestGameTracker_ReloadsOnMismatch(t *testing.T) {
+	root := NewPosition(0, 0)
+	attack := root.Attack()
+	fetcher := &mockClaimFetcher{
+		claims: []mockClaimData{
+			{ParentIndex: math.MaxUint32, Claim: common.Hash{0x01}, Position: new(big.Int).SetUint64(root.ToGIndex())},
+		},
+	}
+	tracker := newTestTracker(t, fetcher)
+	require.NoError(t, tracker.Load(context.Background()))
+
+	fetcher.claims[0].Countered = true
+	fetcher.claims = append(fetcher.claims, mockClaimData{ParentIndex: 0, Claim: common.Hash{0x02}, Position: new(big.Int).SetUint64(attack.ToGIndex())})
+	require.NoError(t, tracker.ApplyLogs(context.Background(), []types.Log{moveLog(t, 0, common.Hash{0x03})}))
+	require.Len(t, tracker.Game().Claims(), 2)
+
+	removed := moveLog(t, 0, common.Hash{0x02})
+	removed.Removed = true
+	fetcher.claims = fetcher.claims[:1]
+	fetcher.claims[0].Countered = false
+	require.NoError(t, tracker.ApplyLogs(context.Background(), []types.Log{removed}))
+	require.Len(t, tracker.Game().Claims(), 1)
+}
+
+// TestGameTracker_CheckReorgs tests that the game is reloaded when claims were reorged out.
+func TestGameTracker_CheckReorgs(t *testing.T) {
+	root := NewPosition(0, 0)
+	attack := root.Attack()
+	fetcher := &mockClaimFetcher{
+		claims: []mockClaimData{
+			{ParentIndex: math.MaxUint32, Claim: common.Hash{0x01}, Position: new(big.Int).SetUint64(root.ToGIndex())},
+		},
+	}
+	canonical := &types.Header{Number: big.NewInt(7)}
+	headers := &mockHeaderFetcher{headers: map[uint64]*types.Header{7: canonical}, latest: &types.Header{Number: big.NewInt(5)}}
+	tracker := NewGameTracker(testlog.Logger(t, log.LvlError), common.Address{0xfd}, 3, NewLoader(fetcher), nil, headers)
+	reloaded, err := tracker.CheckReorgs(context.Background())
+	require.NoError(t, err)
+	require.False(t, reloaded, "nothing to check before the game is loaded")
+	require.NoError(t, tracker.Load(context.Background()))
+
+	fetcher.claims = append(fetcher.claims, mockClaimData{ParentIndex: 0, Claim: common.Hash{0x02}, Position: new(big.Int).SetUint64(attack.ToGIndex())})
+	move := moveLog(t, 0, common.Hash{0x02})
+	move.BlockHash = canonical.Hash()
+	require.NoError(t, tracker.ApplyLogs(context.Background(), []types.Log{move}))
+	reloaded, err = tracker.CheckReorgs(context.Background())
+	require.NoError(t, err)
+	require.False(t, reloaded)
+	require.Len(t, tracker.Game().Claims(), 2)
+
+	// The block of the move is replaced and the move is not included in the new chain.
+	headers.headers[7] = &types.Header{Number: big.NewInt(7), Extra: []byte{0x01}}
+	fetcher.claims = fetcher.claims[:1]
+	reloaded, err = tracker.CheckReorgs(context.Background())
+	require.NoError(t, err)
+	require.True(t, reloaded)
+	require.Len(t, tracker.Game().Claims(), 1)
+}
+
+// TestGameTracker_RejectsMalformedClaims tests that claims that are not valid moves are not tracked.
+func TestGameTracker_RejectsMalformedClaims(t *testing.T) {
+	root := NewPosition(0, 0)
+	attack := root.Attack()
+	fetcher := &mockClaimFetcher{
+		claims: []mockClaimData{
+			{ParentIndex: math.MaxUint32, Claim: common.Hash{0x01}, Position: new(big.Int).SetUint64(root.ToGIndex())},
+			{ParentIndex: 0, Claim: common.Hash{0x02}, Position: new(big.Int).SetUint64(attack.ToGIndex())},
+		},
+	}
+	// The root claim has a child but is not countered.
+	tracker := newTestTracker(t, fetcher)
+	require.ErrorIs(t, tracker.Load(context.Background()), ErrMalformedClaim)
+	require.Nil(t, tracker.Game())
+
+	fetcher.claims = fetcher.claims[:1]
+	require.NoError(t, tracker.Load(context.Background()))
+
+	// The new claim is not at the attack position of the root.
+	deep := attack.Attack()
+	fetcher.claims = append(fetcher.claims, mockClaimData{ParentIndex: 0, Claim: common.Hash{0x02}, Position: new(big.Int).SetUint64(deep.ToGIndex())})
+	require.ErrorIs(t, tracker.ApplyLogs(context.Background(), []types.Log{moveLog(t, 0, common.Hash{0x02})}), ErrMalformedClaim)
+	require.Len(t, tracker.Game().Claims(), 1)
+}
@@ -0,0 +1,178 @@
+package fault
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	// TxAuditIntent is the event of an entry written before a move transaction is sent.
+	TxAuditIntent = "intent"
+	// TxAuditResult is the event of an entry written once the outcome of a move transaction is known.
+	TxAuditResult = "result"
+)
+
+// ErrTxAuditChainBroken is returned when an entry of a [TxAuditLog] does not hold the hash of the line
+// before it, e.g. because an entry was removed or edited.
+var ErrTxAuditChainBroken = errors.New("audit log hash chain is broken")
+
+// TxAuditEntry is an entry of a [TxAuditLog].
+type TxAuditEntry struct {
+	Time        int64          `json:"time"`
+	Event       string         `json:"event"`
+	Game        common.Address `json:"game"`
+	Action      string         `json:"action"`
+	ParentIndex int            `json:"parentIndex"`
+	Claim       ClaimData      `json:"claim"`
+	TxData      hexutil.Bytes  `json:"txData"`
+	TxHash      *common.Hash   `json:"txHash,omitempty"`
+	Status      *uint64        `json:"status,omitempty"`
+	Error       string         `json:"error,omitempty"`
+	// Prev is the hash of the previous line of the log, so that removed or edited entries can be detected.
+	Prev common.Hash `json:"prev"`
+}
+
+// TxAuditLog is an append-only log of every move transaction the challenger signs, persisted
+// as one JSON object per line. Every move has an intent entry written before it is sent and
+// a result entry with its transaction hash or error. Each entry holds the hash of the line before it.
+type TxAuditLog struct {
+	mu   sync.Mutex
+	file *os.File
+	prev common.Hash
+}
+
+// OpenTxAuditLog opens the [TxAuditLog] at path, creating the file if it does not exist.
+// An incomplete last line, e.g. from a crash while it was written, is removed.
+// The hash chain of the existing entries is verified, and [ErrTxAuditChainBroken] is returned if
+// it is broken, so that new entries are never chained to a log that was tampered with.
+func OpenTxAuditLog(path string) (*TxAuditLog, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+	data, err := readCompleteLines(file)
+	if err != nil {
+		_ = file.Close()
+		return nil, fmt.Errorf("failed to read audit log: %w", err)
+	}
+	prev, err := verifyTxAuditChain(data)
+	if err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+	return &TxAuditLog{file: file, prev: prev}, nil
+}
+
+// verifyTxAuditChain checks that every line of the log holds the hash of the line before it,
+// and the first line the zero hash. It returns the hash of the last line.
+func verifyTxAuditChain(data []byte) (common.Hash, error) {
+	var prev common.Hash
+	for i := 0; len(data) > 0; i++ {
+		end := bytes.IndexByte(data, '\n')
+		line := data[:end]
+		data = data[end+1:]
+		var entry struct {
+			Prev common.Hash `json:"prev"`
+		}
+		if err := json.Unmarshal(line, &entry); err != nil {
+			return common.Hash{}, fmt.Errorf("%w: entry %d: %v", ErrTxAuditChainBroken, i, err)
+		}
+		if entry.Prev != prev {
+			return common.Hash{}, fmt.Errorf("%w: entry %d holds %v, previous line hashes to %v", ErrTxAuditChainBroken, i, entry.Prev, prev)
+		}
+		prev = crypto.Keccak256Hash(line)
+	}
+	return prev, nil
+}
+
+// Append writes the entry to the log, chained to the previous entry, and syncs it to disk.
+func (l *TxAuditLog) Append(entry TxAuditEntry) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	entry.Prev = l.prev
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if _, err := l.file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write audit log: %w", err)
+	}
+	if err := l.file.Sync(); err != nil {
+		return fmt.Errorf("failed to sync audit log: %w", err)
+	}
+	l.prev = crypto.Keccak256Hash(line)
+	return nil
+}
+
+// Close closes the file of the log.
+func (l *TxAuditLog) Close() error {
+	return l.file.Close()
+}
+
+// AuditedResponder wraps a [FaultResponder] and records every move it sends in a [TxAuditLog].
+// A move is only sent once its intent was recorded.
+type AuditedResponder struct {
+	responder *FaultResponder
+	log       *TxAuditLog
+	game      common.Address
+}
+
+// NewAuditedResponder returns an [AuditedResponder] for the game at gameAddr.
+func NewAuditedResponder(responder *FaultResponder, log *TxAuditLog, gameAddr common.Address) *AuditedResponder {
+	return &AuditedResponder{
+		responder: responder,
+		log:       log,
+		game:      gameAddr,
+	}
+}
+
+// Respond records the intent to send the response, sends it and records the result.
+func (a *AuditedResponder) Respond(ctx context.Context, response Claim) error {
+	txData, err := a.responder.BuildTx(ctx, response)
+	if err != nil {
+		return err
+	}
+	entry := TxAuditEntry{
+		Time:        time.Now().Unix(),
+		Event:       TxAuditIntent,
+		Game:        a.game,
+		Action:      "attack",
+		ParentIndex: response.ParentContractIndex,
+		Claim:       response.ClaimData,
+		TxData:      txData,
+	}
+	if response.DefendsParent() {
+		entry.Action = "defend"
+	}
+	if err := a.log.Append(entry); err != nil {
+		return err
+	}
+
+	receipt, sendErr := a.responder.Send(ctx, response)
+	entry.Time = time.Now().Unix()
+	entry.Event = TxAuditResult
+	if receipt != nil {
+		entry.TxHash = &receipt.TxHash
+		entry.Status = &receipt.Status
+	}
+	if sendErr != nil {
+		entry.Error = sendErr.Error()
+	}
+	if err := a.log.Append(entry); err != nil {
+		if sendErr != nil {
+			return sendErr
+		}
+		return err
+	}
+	return sendErr
+}
@@ -0,0 +1,144 @@
+package fault
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func readTxAuditLog(t *testing.T, path string) ([]TxAuditEntry, [][]byte) {
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+	var entries []TxAuditEntry
+	var lines [][]byte
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var entry TxAuditEntry
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &entry))
+		entries = append(entries, entry)
+		lines = append(lines, append([]byte(nil), scanner.Bytes()...))
+	}
+	require.NoError(t, scanner.Err())
+	return entries, lines
+}
+
+// TestAuditedResponder tests that the intent and result of every move are recorded and chained.
+func TestAuditedResponder(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	auditLog, err := OpenTxAuditLog(path)
+	require.NoError(t, err)
+	txMgr := &mockTxManager{status: 1}
+	game := common.Address{0xfd}
+	responder := NewAuditedResponder(newTestFaultResponder(t, txMgr), auditLog, game)
+
+	parent := NewPosition(1, 0)
+	attack := Claim{ClaimData: ClaimData{Value: common.Hash{0x01}, Position: parent.Attack()}, Parent: ClaimData{Position: parent}, ParentContractIndex: 1}
+	require.NoError(t, responder.Respond(context.Background(), attack))
+	txMgr.sendErr = mockSendError
+	require.ErrorIs(t, responder.Respond(context.Background(), attack), mockSendError)
+	require.NoError(t, auditLog.Close())
+
+	entries, lines := readTxAuditLog(t, path)
+	require.Len(t, entries, 4)
+	require.Equal(t, TxAuditIntent, entries[0].Event)
+	require.Equal(t, game, entries[0].Game)
+	require.Equal(t, "attack", entries[0].Action)
+	require.Equal(t, attack.ClaimData, entries[0].Claim)
+	require.Equal(t, []byte(txMgr.sent[0].TxData), []byte(entries[0].TxData))
+	require.Nil(t, entries[0].TxHash)
+	require.Equal(t, TxAuditResult, entries[1].Event)
+	require.NotNil(t, entries[1].TxHash)
+	require.Equal(t, uint64(1), *entries[1].Status)
+	require.Empty(t, entries[1].Error)
+	require.Equal(t, TxAuditResult, entries[3].Event)
+	require.Nil(t, entries[3].TxHash)
+	require.Equal(t, mockSendError.Error(), entries[3].Error)
+
+	require.Equal(t, common.Hash{}, entries[0].Prev)
+	for i := 1; i < len(entries); i++ {
+		require.Equal(t, crypto.Keccak256Hash(lines[i-1]), entries[i].Prev)
+	}
+}
+
+// TestOpenTxAuditLog_TornLine tests that an incomplete last entry is removed and the chain continues.
+func TestOpenTxAuditLog_TornLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	auditLog, err := OpenTxAuditLog(path)
+	require.NoError(t, err)
+	require.NoError(t, auditLog.Append(TxAuditEntry{Event: TxAuditIntent}))
+	require.NoError(t, auditLog.Close())
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0o600)
+	require.NoError(t, err)
+	_, err = file.WriteString(`{"event":"res`)
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	auditLog, err = OpenTxAuditLog(path)
+	require.NoError(t, err)
+	require.NoError(t, auditLog.Append(TxAuditEntry{Event: TxAuditResult}))
+	require.NoError(t, auditLog.Close())
+
+	entries, lines := readTxAuditLog(t, path)
+	require.Len(t, entries, 2)
+	require.Equal(t, TxAuditResult, entries[1].Event)
+	require.Equal(t, crypto.Keccak256Hash(lines[0]), entries[1].Prev)
+}
+
+// TestOpenTxAuditLog_BrokenChain tests that logs with removed or edited entries are rejected.
+func TestOpenTxAuditLog_BrokenChain(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	auditLog, err := OpenTxAuditLog(path)
+	require.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		require.NoError(t, auditLog.Append(TxAuditEntry{Event: TxAuditIntent, ParentIndex: i}))
+	}
+	require.NoError(t, auditLog.Close())
+	_, lines := readTxAuditLog(t, path)
+
+	join := func(lines ...[]byte) []byte {
+		var data []byte
+		for _, line := range lines {
+			data = append(append(data, line...), '\n')
+		}
+		return data
+	}
+	edited := bytes.Replace(lines[1], []byte(`"parentIndex":1`), []byte(`"parentIndex":7`), 1)
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"RemovedFirst", join(lines[1], lines[2])},
+		{"RemovedMiddle", join(lines[0], lines[2])},
+		{"Edited", join(lines[0], edited, lines[2])},
+		{"Reordered", join(lines[0], lines[2], lines[1])},
+		{"NotJSON", join(lines[0], []byte("not json"))},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "audit.jsonl")
+			require.NoError(t, os.WriteFile(path, test.data, 0o600))
+			_, err := OpenTxAuditLog(path)
+			require.ErrorIs(t, err, ErrTxAuditChainBroken)
+		})
+	}
+
+	// The intact log can still be opened and appended to.
+	auditLog, err = OpenTxAuditLog(path)
+	require.NoError(t, err)
+	require.NoError(t, auditLog.Append(TxAuditEntry{Event: TxAuditResult}))
+	require.NoError(t, auditLog.Close())
+	auditLog, err = OpenTxAuditLog(path)
+	require.NoError(t, err)
+	require.NoError(t, auditLog.Close())
+}
@@ -31,11 +31,28 @@ type ClaimData struct {
 // and the Parent field is empty & meaningless.
 type Claim struct {
 	ClaimData
+	// Countered is true if the claim has been countered in the contract.
+	Countered bool
+	// Clock is the chess clock of the claim. It is only set for claims loaded from the contract.
+	Clock  Clock
 	Parent ClaimData
 	// Location of the claim & it's parent inside the contract. Does not exist
 	// for claims that have not made it to the contract.
 	ContractIndex       int
 	ParentContractIndex int
+	// Provenance links the claim to the transaction that added it. It is only set for
+	// claims whose Move event was seen, e.g. by [Loader.ApplyEvents] or [AnnotateClaims].
+	Provenance Provenance
+}
+
+// Provenance identifies the L1 transaction that added a claim to the contract.
+// The time the claim was made is the timestamp of its [Clock].
+type Provenance struct {
+	L1Block     uint64
+	L1BlockHash common.Hash
+	TxHash      common.Hash
+	// Claimant is the account that made the move.
+	Claimant common.Address
 }
 
 // IsRoot returns true if this claim is the root claim.
@@ -45,8 +62,10 @@ func (c *Claim) IsRoot() bool {
 
 // DefendsParent returns true if the the claim is a defense (i.e. goes right) of the
 // parent. It returns false if the claim is an attack (i.e. goes left) of the parent.
+// A defense is a child of a sibling of the parent, so its index divided by the branching
+// factor is not the index of the parent.
 func (c *Claim) DefendsParent() bool {
-	return (c.IndexAtDepth() >> 1) != c.Parent.IndexAtDepth()
+	return c.IndexAtDepth()/c.Parent.Branching() != c.Parent.IndexAtDepth()
 }
 
 // Responder takes a response action & executes.
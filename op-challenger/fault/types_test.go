@@ -0,0 +1,22 @@
+package fault
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestClaim_DefendsParent tests that attacks and defenses are told apart for any branching factor.
+func TestClaim_DefendsParent(t *testing.T) {
+	for _, branching := range []int{BinaryBranching, 3, 4} {
+		parent := ClaimData{Position: NewPositionWithBranching(1, 0, branching)}
+		attack := Claim{ClaimData: ClaimData{Position: parent.Attack()}, Parent: parent}
+		require.False(t, attack.DefendsParent(), "attack with branching %d", branching)
+		for child := 1; child < branching; child++ {
+			move := Claim{ClaimData: ClaimData{Position: parent.Child(child)}, Parent: parent}
+			require.False(t, move.DefendsParent(), "child %d with branching %d", child, branching)
+		}
+		defend := Claim{ClaimData: ClaimData{Position: parent.Defend()}, Parent: parent}
+		require.True(t, defend.DefendsParent(), "defend with branching %d", branching)
+	}
+}
@@ -0,0 +1,62 @@
+package fault
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrMalformedClaim is returned when claims loaded from a game break the invariants the contract
+// enforces. The solver assumes well-formed claims, so such a game must not be played.
+var ErrMalformedClaim = errors.New("malformed claim")
+
+// ValidateClaims checks that the claims, in contract order as returned by [Loader.FetchClaims],
+// are consistent with the moves the contract allows in a game of maxDepth:
+//   - only the first claim is at the root position,
+//   - contract indices match the order of the claims,
+//   - every parent was added before its child and matches the parent of the claim,
+//   - every claim is at the attack or defend position of its parent, within maxDepth,
+//   - a claim with children is countered. A claim may also be countered without children,
+//     as a successful step counters a leaf claim without adding a new claim.
+func ValidateClaims(claims []Claim, maxDepth int) error {
+	if len(claims) == 0 {
+		return fmt.Errorf("%w: game has no claims", ErrMalformedClaim)
+	}
+	hasChild := make([]bool, len(claims))
+	for i, claim := range claims {
+		if claim.ContractIndex != i {
+			return fmt.Errorf("%w: claim %d has contract index %d", ErrMalformedClaim, i, claim.ContractIndex)
+		}
+		if claim.Depth() > maxDepth {
+			return fmt.Errorf("%w: claim %d at depth %d exceeds max depth %d", ErrMalformedClaim, i, claim.Depth(), maxDepth)
+		}
+		if i == 0 {
+			if !claim.IsRoot() {
+				return fmt.Errorf("%w: first claim is at depth %d index %d, not the root", ErrMalformedClaim, claim.Depth(), claim.IndexAtDepth())
+			}
+			continue
+		}
+		if claim.IsRoot() {
+			return fmt.Errorf("%w: claim %d is at the root position", ErrMalformedClaim, i)
+		}
+		if claim.ParentContractIndex < 0 || claim.ParentContractIndex >= i {
+			return fmt.Errorf("%w: claim %d has parent index %d", ErrMalformedClaim, i, claim.ParentContractIndex)
+		}
+		parent := claims[claim.ParentContractIndex]
+		if claim.Parent != parent.ClaimData {
+			return fmt.Errorf("%w: claim %d does not match its parent %d", ErrMalformedClaim, i, parent.ContractIndex)
+		}
+		attack := parent.Attack()
+		defend := parent.Defend()
+		if claim.Position != attack && (parent.IsRoot() || claim.Position != defend) {
+			return fmt.Errorf("%w: claim %d at depth %d index %d is not a move against its parent at depth %d index %d",
+				ErrMalformedClaim, i, claim.Depth(), claim.IndexAtDepth(), parent.Depth(), parent.IndexAtDepth())
+		}
+		hasChild[claim.ParentContractIndex] = true
+	}
+	for i, claim := range claims {
+		if hasChild[i] && !claim.Countered {
+			return fmt.Errorf("%w: claim %d has children but is not countered", ErrMalformedClaim, i)
+		}
+	}
+	return nil
+}
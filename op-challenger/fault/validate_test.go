@@ -0,0 +1,77 @@
+package fault
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+// TestValidateClaims tests that claims breaking the contract invariants are rejected.
+func TestValidateClaims(t *testing.T) {
+	maxDepth := 2
+	newClaims := func() []Claim {
+		root := Claim{ClaimData: ClaimData{Value: common.Hash{0x01}, Position: NewPosition(0, 0)}, Countered: true}
+		attack := Claim{ClaimData: ClaimData{Value: common.Hash{0x02}, Position: NewPosition(1, 0)}, Countered: true, Parent: root.ClaimData, ContractIndex: 1}
+		defend := Claim{ClaimData: ClaimData{Value: common.Hash{0x03}, Position: NewPosition(2, 2)}, Parent: attack.ClaimData, ContractIndex: 2, ParentContractIndex: 1}
+		return []Claim{root, attack, defend}
+	}
+	require.NoError(t, ValidateClaims(newClaims(), maxDepth))
+
+	t.Run("SteppedLeaf", func(t *testing.T) {
+		claims := newClaims()
+		// A successful step counters the leaf claim without adding a child.
+		claims[2].Countered = true
+		require.NoError(t, ValidateClaims(claims, maxDepth))
+	})
+
+	tests := []struct {
+		name   string
+		mutate func(claims []Claim) []Claim
+	}{
+		{"NoClaims", func(claims []Claim) []Claim { return nil }},
+		{"RootNotFirst", func(claims []Claim) []Claim {
+			claims[0].Position = NewPosition(1, 0)
+			return claims
+		}},
+		{"SecondRoot", func(claims []Claim) []Claim {
+			claims[2].Position = NewPosition(0, 0)
+			return claims
+		}},
+		{"WrongContractIndex", func(claims []Claim) []Claim {
+			claims[2].ContractIndex = 5
+			return claims
+		}},
+		{"ExceedsMaxDepth", func(claims []Claim) []Claim {
+			claims[2].Position = NewPosition(3, 4)
+			return claims
+		}},
+		{"ParentAfterChild", func(claims []Claim) []Claim {
+			claims[1].ParentContractIndex = 2
+			return claims
+		}},
+		{"ParentMismatch", func(claims []Claim) []Claim {
+			claims[2].Parent = claims[0].ClaimData
+			return claims
+		}},
+		{"NotAMove", func(claims []Claim) []Claim {
+			claims[2].Position = NewPosition(2, 3)
+			return claims
+		}},
+		{"DefendRoot", func(claims []Claim) []Claim {
+			claims[1].Position = NewPosition(1, 1)
+			claims[2].Parent = claims[1].ClaimData
+			return claims
+		}},
+		{"UncounteredWithChild", func(claims []Claim) []Claim {
+			claims[1].Countered = false
+			return claims
+		}},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			require.ErrorIs(t, ValidateClaims(test.mutate(newClaims()), maxDepth), ErrMalformedClaim)
+		})
+	}
+}
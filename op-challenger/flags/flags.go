@@ -2,9 +2,11 @@ package flags
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/urfave/cli/v2"
 
+	challengerrpc "github.com/ethereum-optimism/optimism/op-challenger/rpc"
 	opservice "github.com/ethereum-optimism/optimism/op-service"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
@@ -43,6 +45,32 @@ var (
 	}
 )
 
+var (
+	// Optional Flags
+	L1EthRpcFallbackFlag = &cli.StringSliceFlag{
+		Name:    "l1-eth-rpc-fallback",
+		Usage:   "Additional L1 provider URLs that contract reads fail over to, in order of priority, when the --l1-eth-rpc provider is unavailable.",
+		EnvVars: prefixEnvVars("L1_ETH_RPC_FALLBACK"),
+	}
+	L1EthRpcMaxLatencyFlag = &cli.DurationFlag{
+		Name:    "l1-eth-rpc-max-latency",
+		Usage:   "90th percentile latency above which an L1 provider is demoted behind the other providers, when fallbacks are configured. Zero disables the check.",
+		EnvVars: prefixEnvVars("L1_ETH_RPC_MAX_LATENCY"),
+		Value:   5 * time.Second,
+	}
+	L1EthRpcMaxErrorRateFlag = &cli.Float64Flag{
+		Name:    "l1-eth-rpc-max-error-rate",
+		Usage:   "Fraction of failed requests above which an L1 provider is demoted behind the other providers, when fallbacks are configured. Zero disables the check.",
+		EnvVars: prefixEnvVars("L1_ETH_RPC_MAX_ERROR_RATE"),
+		Value:   0.25,
+	}
+	L1EthRpcRateLimitFlag = &cli.Float64Flag{
+		Name:    "l1-eth-rpc-rate-limit",
+		Usage:   "Rate (in requests / second) that each method of the L1 providers is limited to. The limit is lowered while a provider reports rate limiting. Zero disables the limit.",
+		EnvVars: prefixEnvVars("L1_ETH_RPC_RATE_LIMIT"),
+	}
+)
+
 // requiredFlags are checked by [CheckRequired]
 var requiredFlags = []cli.Flag{
 	L1EthRpcFlag,
@@ -52,10 +80,16 @@ var requiredFlags = []cli.Flag{
 }
 
 // optionalFlags is a list of unchecked cli flags
-var optionalFlags = []cli.Flag{}
+var optionalFlags = []cli.Flag{
+	L1EthRpcFallbackFlag,
+	L1EthRpcMaxLatencyFlag,
+	L1EthRpcMaxErrorRateFlag,
+	L1EthRpcRateLimitFlag,
+}
 
 func init() {
 	optionalFlags = append(optionalFlags, oprpc.CLIFlags(envVarPrefix)...)
+	optionalFlags = append(optionalFlags, challengerrpc.CLIFlags(envVarPrefix)...)
 	optionalFlags = append(optionalFlags, oplog.CLIFlags(envVarPrefix)...)
 	optionalFlags = append(optionalFlags, opmetrics.CLIFlags(envVarPrefix)...)
 	optionalFlags = append(optionalFlags, oppprof.CLIFlags(envVarPrefix)...)
@@ -2,6 +2,8 @@ package metrics
 
 import (
 	"context"
+	"strconv"
+	"time"
 
 	"github.com/ethereum-optimism/optimism/op-node/eth"
 
@@ -29,6 +31,15 @@ type Metricer interface {
 	RecordValidOutput(l2ref eth.L2BlockRef)
 	RecordInvalidOutput(l2ref eth.L2BlockRef)
 	RecordOutputChallenged(l2ref eth.L2BlockRef)
+
+	RecordSolverTick(game common.Address, claims int, duration time.Duration)
+	RecordSolverDecision(decision string, reason string)
+	RecordTraceAudit(checked int, mismatches int)
+	RecordAgentPanic()
+
+	// Records the health of the L1 fallback endpoints
+	RecordRPCEndpointStats(endpoint int, p50, p90, p99 time.Duration, errorRate float64)
+	RecordRPCEndpointDemoted(endpoint int, demoted bool)
 }
 
 type Metrics struct {
@@ -41,6 +52,18 @@ type Metrics struct {
 
 	info prometheus.GaugeVec
 	up   prometheus.Gauge
+
+	solverTickClaims    *prometheus.HistogramVec
+	solverTickDuration  *prometheus.HistogramVec
+	solverDecisionTotal *prometheus.CounterVec
+	agentPanicsTotal    prometheus.Counter
+
+	traceAuditChecksTotal     prometheus.Counter
+	traceAuditMismatchesTotal prometheus.Counter
+
+	rpcEndpointLatency   *prometheus.GaugeVec
+	rpcEndpointErrorRate *prometheus.GaugeVec
+	rpcEndpointDemoted   *prometheus.GaugeVec
 }
 
 var _ Metricer = (*Metrics)(nil)
@@ -74,6 +97,67 @@ func NewMetrics(procName string) *Metrics {
 			Name:      "up",
 			Help:      "1 if the op-proposer has finished starting up",
 		}),
+		solverTickClaims: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: ns,
+			Name:      "solver_tick_claims",
+			Help:      "Number of claims evaluated by the solver per tick, by game",
+			Buckets:   prometheus.ExponentialBuckets(1, 2, 10),
+		}, []string{
+			"game",
+		}),
+		solverTickDuration: factory.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: ns,
+			Name:      "solver_tick_duration_seconds",
+			Help:      "Time taken by a solver tick, including sending the moves, by game",
+			Buckets:   prometheus.ExponentialBuckets(0.01, 2, 12),
+		}, []string{
+			"game",
+		}),
+		solverDecisionTotal: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "solver_decisions_total",
+			Help:      "Number of solver decisions, by the move made and the reason for it",
+		}, []string{
+			"decision",
+			"reason",
+		}),
+		agentPanicsTotal: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "agent_panics_total",
+			Help:      "Number of agents that panicked while performing actions and were quarantined",
+		}),
+		traceAuditChecksTotal: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "trace_audit_checks_total",
+			Help:      "Number of trace values re-derived to check that the trace provider is deterministic",
+		}),
+		traceAuditMismatchesTotal: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "trace_audit_mismatches_total",
+			Help:      "Number of re-derived trace values that differed from the value computed before",
+		}),
+		rpcEndpointLatency: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "rpc_endpoint_latency_seconds",
+			Help:      "Latency percentiles of the recent requests to an L1 endpoint",
+		}, []string{
+			"endpoint",
+			"quantile",
+		}),
+		rpcEndpointErrorRate: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "rpc_endpoint_error_rate",
+			Help:      "Fraction of the recent requests to an L1 endpoint that failed",
+		}, []string{
+			"endpoint",
+		}),
+		rpcEndpointDemoted: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "rpc_endpoint_demoted",
+			Help:      "1 if the L1 endpoint is demoted behind the other endpoints",
+		}, []string{
+			"endpoint",
+		}),
 	}
 }
 
@@ -119,6 +203,46 @@ func (m *Metrics) RecordOutputChallenged(l2ref eth.L2BlockRef) {
 	m.RecordL2Ref(OutputChallenged, l2ref)
 }
 
+// RecordSolverTick records the number of claims evaluated by a solver tick of a game and how long it took.
+func (m *Metrics) RecordSolverTick(game common.Address, claims int, duration time.Duration) {
+	m.solverTickClaims.WithLabelValues(game.Hex()).Observe(float64(claims))
+	m.solverTickDuration.WithLabelValues(game.Hex()).Observe(duration.Seconds())
+}
+
+// RecordSolverDecision records a decision of the solver on a claim.
+func (m *Metrics) RecordSolverDecision(decision string, reason string) {
+	m.solverDecisionTotal.WithLabelValues(decision, reason).Inc()
+}
+
+// RecordAgentPanic records that an agent panicked and its game was quarantined.
+func (m *Metrics) RecordAgentPanic() {
+	m.agentPanicsTotal.Inc()
+}
+
+// RecordTraceAudit records how many trace values were re-derived and how many of them changed.
+func (m *Metrics) RecordTraceAudit(checked int, mismatches int) {
+	m.traceAuditChecksTotal.Add(float64(checked))
+	m.traceAuditMismatchesTotal.Add(float64(mismatches))
+}
+
+// RecordRPCEndpointStats records the latency percentiles and error rate of the recent requests to an L1 endpoint.
+func (m *Metrics) RecordRPCEndpointStats(endpoint int, p50, p90, p99 time.Duration, errorRate float64) {
+	label := strconv.Itoa(endpoint)
+	m.rpcEndpointLatency.WithLabelValues(label, "0.5").Set(p50.Seconds())
+	m.rpcEndpointLatency.WithLabelValues(label, "0.9").Set(p90.Seconds())
+	m.rpcEndpointLatency.WithLabelValues(label, "0.99").Set(p99.Seconds())
+	m.rpcEndpointErrorRate.WithLabelValues(label).Set(errorRate)
+}
+
+// RecordRPCEndpointDemoted records whether an L1 endpoint is demoted.
+func (m *Metrics) RecordRPCEndpointDemoted(endpoint int, demoted bool) {
+	var val float64
+	if demoted {
+		val = 1
+	}
+	m.rpcEndpointDemoted.WithLabelValues(strconv.Itoa(endpoint)).Set(val)
+}
+
 func (m *Metrics) Document() []opmetrics.DocumentedMetric {
 	return m.factory.Document()
 }
@@ -1,6 +1,10 @@
 package metrics
 
 import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
 	"github.com/ethereum-optimism/optimism/op-node/eth"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	txmetrics "github.com/ethereum-optimism/optimism/op-service/txmgr/metrics"
@@ -19,3 +23,12 @@ func (*noopMetrics) RecordUp()                 {}
 func (*noopMetrics) RecordValidOutput(l2ref eth.L2BlockRef)      {}
 func (*noopMetrics) RecordInvalidOutput(l2ref eth.L2BlockRef)    {}
 func (*noopMetrics) RecordOutputChallenged(l2ref eth.L2BlockRef) {}
+
+func (*noopMetrics) RecordSolverTick(game common.Address, claims int, duration time.Duration) {}
+func (*noopMetrics) RecordSolverDecision(decision string, reason string)                      {}
+func (*noopMetrics) RecordAgentPanic()                                                        {}
+func (*noopMetrics) RecordTraceAudit(checked int, mismatches int)                             {}
+
+func (*noopMetrics) RecordRPCEndpointStats(endpoint int, p50, p90, p99 time.Duration, errorRate float64) {
+}
+func (*noopMetrics) RecordRPCEndpointDemoted(endpoint int, demoted bool) {}
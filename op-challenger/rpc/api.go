@@ -0,0 +1,61 @@
+package rpc
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/fault"
+)
+
+type challengerControls interface {
+	Pause()
+	Resume()
+	Paused() bool
+	IgnoreGame(game common.Address)
+	UnignoreGame(game common.Address)
+	IgnoredGames() []common.Address
+	InFlight() []fault.InFlightMove
+}
+
+type adminAPI struct {
+	c challengerControls
+}
+
+func NewAdminAPI(c challengerControls) *adminAPI {
+	return &adminAPI{
+		c: c,
+	}
+}
+
+func (a *adminAPI) PauseSending(_ context.Context) error {
+	a.c.Pause()
+	return nil
+}
+
+func (a *adminAPI) ResumeSending(_ context.Context) error {
+	a.c.Resume()
+	return nil
+}
+
+func (a *adminAPI) SendingPaused(_ context.Context) (bool, error) {
+	return a.c.Paused(), nil
+}
+
+func (a *adminAPI) IgnoreGame(_ context.Context, game common.Address) error {
+	a.c.IgnoreGame(game)
+	return nil
+}
+
+func (a *adminAPI) UnignoreGame(_ context.Context, game common.Address) error {
+	a.c.UnignoreGame(game)
+	return nil
+}
+
+func (a *adminAPI) IgnoredGames(_ context.Context) ([]common.Address, error) {
+	return a.c.IgnoredGames(), nil
+}
+
+func (a *adminAPI) InFlightMoves(_ context.Context) ([]fault.InFlightMove, error) {
+	return a.c.InFlight(), nil
+}
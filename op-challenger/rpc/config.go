@@ -0,0 +1,68 @@
+package rpc
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli/v2"
+
+	opservice "github.com/ethereum-optimism/optimism/op-service"
+)
+
+const (
+	EnableAdminFlagName = "rpc.enable-admin"
+	JWTSecretFlagName   = "rpc.jwt-secret"
+)
+
+var ErrMissingJWTSecret = errors.New("the admin API requires a JWT secret")
+
+func CLIFlags(envPrefix string) []cli.Flag {
+	return []cli.Flag{
+		&cli.BoolFlag{
+			Name:    EnableAdminFlagName,
+			Usage:   "Enable the admin API (experimental). The challenger does not send moves yet, so the controls of the API have no effect.",
+			EnvVars: opservice.PrefixEnvVar(envPrefix, "RPC_ENABLE_ADMIN"),
+		},
+		&cli.StringFlag{
+			Name:      JWTSecretFlagName,
+			Usage:     "Path to a file containing the hex-encoded 32 byte JWT secret used to authenticate RPC requests. Required by the admin API.",
+			EnvVars:   opservice.PrefixEnvVar(envPrefix, "RPC_JWT_SECRET"),
+			TakesFile: true,
+		},
+	}
+}
+
+type CLIConfig struct {
+	EnableAdmin   bool
+	JWTSecretPath string
+}
+
+func (c CLIConfig) Check() error {
+	if c.EnableAdmin && c.JWTSecretPath == "" {
+		return ErrMissingJWTSecret
+	}
+	return nil
+}
+
+// JWTSecret reads the JWT secret from the configured file.
+func (c CLIConfig) JWTSecret() ([]byte, error) {
+	data, err := os.ReadFile(c.JWTSecretPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read JWT secret: %w", err)
+	}
+	secret := common.FromHex(strings.TrimSpace(string(data)))
+	if len(secret) != 32 {
+		return nil, fmt.Errorf("invalid JWT secret in %s, expected 32 bytes", c.JWTSecretPath)
+	}
+	return secret, nil
+}
+
+func ReadCLIConfig(ctx *cli.Context) CLIConfig {
+	return CLIConfig{
+		EnableAdmin:   ctx.Bool(EnableAdminFlagName),
+		JWTSecretPath: ctx.String(JWTSecretFlagName),
+	}
+}
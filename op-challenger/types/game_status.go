@@ -0,0 +1,36 @@
+package types
+
+import "fmt"
+
+// GameStatus is the status of a dispute game, as reported by the contract.
+type GameStatus uint8
+
+const (
+	// GameStatusInProgress is the status of a game that has not been resolved yet.
+	GameStatusInProgress GameStatus = iota
+	// GameStatusChallengerWon is the status of a game resolved in favour of the challenger.
+	GameStatusChallengerWon
+	// GameStatusDefenderWon is the status of a game resolved in favour of the defender.
+	GameStatusDefenderWon
+)
+
+// GameStatuses is a list of dispute game statuses.
+var GameStatuses = []string{"in-progress", "challenger-won", "defender-won"}
+
+// String returns the string value of a game status.
+func (s GameStatus) String() string {
+	if int(s) >= len(GameStatuses) {
+		return fmt.Sprintf("invalid status: %d", s)
+	}
+	return GameStatuses[s]
+}
+
+// GameStatusFromString parses a game status from its string value.
+func GameStatusFromString(value string) (GameStatus, error) {
+	for i, status := range GameStatuses {
+		if status == value {
+			return GameStatus(i), nil
+		}
+	}
+	return 0, fmt.Errorf("invalid game status %q, allowed values are %v", value, GameStatuses)
+}
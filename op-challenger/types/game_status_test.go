@@ -0,0 +1,23 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestGameStatus_RoundTrip tests that every status parses back from its string value.
+func TestGameStatus_RoundTrip(t *testing.T) {
+	for _, status := range []GameStatus{GameStatusInProgress, GameStatusChallengerWon, GameStatusDefenderWon} {
+		parsed, err := GameStatusFromString(status.String())
+		require.NoError(t, err)
+		require.Equal(t, status, parsed)
+	}
+}
+
+// TestGameStatus_Invalid tests parsing and printing of invalid statuses.
+func TestGameStatus_Invalid(t *testing.T) {
+	_, err := GameStatusFromString("resolved")
+	require.Error(t, err)
+	require.Equal(t, "invalid status: 3", GameStatus(3).String())
+}
@@ -0,0 +1,123 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Errors for the reverts of the fault dispute game contract.
+var (
+	ErrCannotDefendRootClaim = errors.New("cannot defend the root claim")
+	ErrClaimAlreadyExists    = errors.New("claim already exists")
+	ErrClockTimeExceeded     = errors.New("clock time exceeded")
+	ErrGameDepthExceeded     = errors.New("game depth exceeded")
+	ErrGameNotInProgress     = errors.New("game not in progress")
+	ErrInvalidParent         = errors.New("invalid parent")
+	ErrInvalidPrestate       = errors.New("invalid prestate")
+	ErrValidStep             = errors.New("valid step")
+)
+
+// RevertError is a revert of the fault dispute game contract, decoded from the revert data.
+type RevertError struct {
+	// Code is the machine readable code of the revert, e.g. for metric labels.
+	Code string
+	// Benign is true if the revert is expected in normal operation, e.g. because another
+	// party made the same move first or the game ended, rather than pointing to a bug.
+	Benign bool
+	err    error
+}
+
+func (e *RevertError) Error() string {
+	return fmt.Sprintf("game reverted: %v", e.err)
+}
+
+func (e *RevertError) Unwrap() error {
+	return e.err
+}
+
+// revert describes a custom error of the contract. All of them are parameterless.
+type revert struct {
+	name   string
+	err    error
+	benign bool
+}
+
+var reverts = []revert{
+	{name: "CannotDefendRootClaim", err: ErrCannotDefendRootClaim},
+	{name: "ClaimAlreadyExists", err: ErrClaimAlreadyExists, benign: true},
+	{name: "ClockTimeExceeded", err: ErrClockTimeExceeded, benign: true},
+	{name: "GameDepthExceeded", err: ErrGameDepthExceeded},
+	{name: "GameNotInProgress", err: ErrGameNotInProgress, benign: true},
+	{name: "InvalidParent", err: ErrInvalidParent},
+	{name: "InvalidPrestate", err: ErrInvalidPrestate},
+	{name: "ValidStep", err: ErrValidStep, benign: true},
+}
+
+// revertsBySelector maps the 4 byte selector of the custom errors to the revert.
+var revertsBySelector = func() map[[4]byte]revert {
+	out := make(map[[4]byte]revert, len(reverts))
+	for _, r := range reverts {
+		var selector [4]byte
+		copy(selector[:], crypto.Keccak256([]byte(r.name+"()")))
+		out[selector] = r
+	}
+	return out
+}()
+
+// DecodeRevertData decodes the revert data of a call to the game.
+// It returns nil if the data is not one of the game's custom errors.
+func DecodeRevertData(data []byte) *RevertError {
+	if len(data) < 4 {
+		return nil
+	}
+	var selector [4]byte
+	copy(selector[:], data)
+	r, ok := revertsBySelector[selector]
+	if !ok {
+		return nil
+	}
+	return &RevertError{Code: revertCode(r.name), Benign: r.benign, err: r.err}
+}
+
+// DecodeRevert decodes the revert data carried by an RPC error, e.g. from gas estimation.
+// It returns nil if the error has no revert data of one of the game's custom errors.
+func DecodeRevert(err error) *RevertError {
+	var dataErr rpc.DataError
+	if !errors.As(err, &dataErr) {
+		return nil
+	}
+	var data []byte
+	switch v := dataErr.ErrorData().(type) {
+	case string:
+		decoded, err := hexutil.Decode(v)
+		if err != nil {
+			return nil
+		}
+		data = decoded
+	case []byte:
+		data = v
+	default:
+		return nil
+	}
+	return DecodeRevertData(data)
+}
+
+// revertCode converts the name of a custom error to snake case, e.g. ClaimAlreadyExists to claim_already_exists.
+func revertCode(name string) string {
+	var b strings.Builder
+	for i, c := range name {
+		if c >= 'A' && c <= 'Z' {
+			if i > 0 {
+				b.WriteByte('_')
+			}
+			c += 'a' - 'A'
+		}
+		b.WriteRune(c)
+	}
+	return b.String()
+}
@@ -0,0 +1,54 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+)
+
+type mockDataError struct {
+	data interface{}
+}
+
+func (e mockDataError) Error() string          { return "execution reverted" }
+func (e mockDataError) ErrorData() interface{} { return e.data }
+
+// TestDecodeRevertData tests that every custom error of the contract is decoded.
+func TestDecodeRevertData(t *testing.T) {
+	fdgAbi, err := bindings.FaultDisputeGameMetaData.GetAbi()
+	require.NoError(t, err)
+	require.Len(t, fdgAbi.Errors, len(reverts), "all contract errors must be mapped")
+	for _, r := range reverts {
+		abiErr, ok := fdgAbi.Errors[r.name]
+		require.True(t, ok, "unknown contract error %v", r.name)
+		decoded := DecodeRevertData(abiErr.ID[:4])
+		require.NotNil(t, decoded)
+		require.ErrorIs(t, decoded, r.err)
+		require.Equal(t, r.benign, decoded.Benign)
+	}
+
+	require.Nil(t, DecodeRevertData([]byte{0x01, 0x02}))
+	require.Nil(t, DecodeRevertData([]byte{0x01, 0x02, 0x03, 0x04}))
+}
+
+// TestDecodeRevert tests that revert data is decoded from wrapped RPC errors.
+func TestDecodeRevert(t *testing.T) {
+	selector := crypto.Keccak256([]byte("ClaimAlreadyExists()"))[:4]
+	err := fmt.Errorf("failed to estimate gas: %w", mockDataError{data: hexutil.Encode(selector)})
+
+	decoded := DecodeRevert(err)
+	require.NotNil(t, decoded)
+	require.Equal(t, "claim_already_exists", decoded.Code)
+	require.True(t, decoded.Benign)
+	require.ErrorIs(t, decoded, ErrClaimAlreadyExists)
+
+	require.Nil(t, DecodeRevert(errors.New("boom")))
+	require.Nil(t, DecodeRevert(mockDataError{data: "not hex"}))
+	require.Nil(t, DecodeRevert(mockDataError{data: 1}))
+}
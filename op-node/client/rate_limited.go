@@ -2,13 +2,22 @@ package client
 
 import (
 	"context"
+	"errors"
+	"net/http"
+	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/rpc"
 	"golang.org/x/time/rate"
+
+	"github.com/ethereum-optimism/optimism/op-node/metrics"
 )
 
+// limitExceededErrorCode is the EIP-1474 JSON-RPC error code for "limit exceeded",
+// which providers use to signal that requests are being rate limited.
+const limitExceededErrorCode = -32005
+
 // RateLimitingClient is a wrapper around a pure RPC that implements a global rate-limit on requests.
 type RateLimitingClient struct {
 	c  RPC
@@ -50,3 +59,169 @@ func (b *RateLimitingClient) EthSubscribe(ctx context.Context, channel any, args
 	}
 	return b.c.EthSubscribe(ctx, channel, args...)
 }
+
+// AdaptiveRateLimitingClient is a wrapper around a pure RPC that rate-limits requests per method.
+// Each method starts at the configured limit. When the provider reports that a request was rate limited,
+// the limit for that method is halved. Every successful request raises it again by a small step,
+// until the configured limit is reached.
+type AdaptiveRateLimitingClient struct {
+	c     RPC
+	limit rate.Limit
+	burst int
+
+	mtx     sync.Mutex
+	methods map[string]*rate.Limiter
+}
+
+// NewAdaptiveRateLimitingClient implements an adaptive rate-limit per RPC method.
+// The limit is the maximum rate any single method is allowed to reach.
+// Batch requests are tracked as a single method and consume one token per batch element.
+func NewAdaptiveRateLimitingClient(c RPC, limit rate.Limit, burst int) *AdaptiveRateLimitingClient {
+	return &AdaptiveRateLimitingClient{
+		c:       c,
+		limit:   limit,
+		burst:   burst,
+		methods: make(map[string]*rate.Limiter),
+	}
+}
+
+func (b *AdaptiveRateLimitingClient) Close() {
+	b.c.Close()
+}
+
+func (b *AdaptiveRateLimitingClient) CallContext(ctx context.Context, result any, method string, args ...any) error {
+	rl := b.limiter(method)
+	if err := rl.Wait(ctx); err != nil {
+		return err
+	}
+	cCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	err := b.c.CallContext(cCtx, result, method, args...)
+	b.adjust(rl, err)
+	return err
+}
+
+func (b *AdaptiveRateLimitingClient) BatchCallContext(ctx context.Context, batch []rpc.BatchElem) error {
+	rl := b.limiter(metrics.BatchMethod)
+	if err := rl.WaitN(ctx, len(batch)); err != nil {
+		return err
+	}
+	cCtx, cancel := context.WithTimeout(ctx, 20*time.Second)
+	defer cancel()
+	err := b.c.BatchCallContext(cCtx, batch)
+	limitErr := err
+	if limitErr == nil {
+		// The batch as a whole may succeed while individual elements were rate limited.
+		for _, elem := range batch {
+			if IsRateLimitError(elem.Error) {
+				limitErr = elem.Error
+				break
+			}
+		}
+	}
+	b.adjust(rl, limitErr)
+	return err
+}
+
+func (b *AdaptiveRateLimitingClient) EthSubscribe(ctx context.Context, channel any, args ...any) (ethereum.Subscription, error) {
+	rl := b.limiter("eth_subscribe")
+	if err := rl.Wait(ctx); err != nil {
+		return nil, err
+	}
+	sub, err := b.c.EthSubscribe(ctx, channel, args...)
+	b.adjust(rl, err)
+	return sub, err
+}
+
+// Limit returns the current rate limit applied to the given method.
+func (b *AdaptiveRateLimitingClient) Limit(method string) rate.Limit {
+	return b.limiter(method).Limit()
+}
+
+func (b *AdaptiveRateLimitingClient) limiter(method string) *rate.Limiter {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	rl, ok := b.methods[method]
+	if !ok {
+		rl = rate.NewLimiter(b.limit, b.burst)
+		b.methods[method] = rl
+	}
+	return rl
+}
+
+// adjust halves the limit of rl if err signals rate limiting, and otherwise recovers
+// a twentieth of the maximum limit, capped at the maximum.
+func (b *AdaptiveRateLimitingClient) adjust(rl *rate.Limiter, err error) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	current := rl.Limit()
+	if IsRateLimitError(err) {
+		next := current / 2
+		if minLimit := b.limit / 64; next < minLimit {
+			next = minLimit
+		}
+		rl.SetLimit(next)
+	} else if err == nil && current < b.limit {
+		next := current + b.limit/20
+		if next > b.limit {
+			next = b.limit
+		}
+		rl.SetLimit(next)
+	}
+}
+
+// IsRateLimitError returns true if the error indicates the request was rejected by
+// the provider because a rate limit or request quota was exceeded.
+func IsRateLimitError(err error) bool {
+	if err == nil {
+		return false
+	}
+	var httpErr rpc.HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode == http.StatusTooManyRequests
+	}
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) {
+		return rpcErr.ErrorCode() == limitExceededErrorCode
+	}
+	return false
+}
+
+// RateBudgets splits the request rate of a single RPC client between subsystems.
+// Each subsystem gets its own rate limit, so one busy subsystem cannot starve the others.
+type RateBudgets struct {
+	c RPC
+
+	mtx      sync.Mutex
+	limiters map[string]*rate.Limiter
+}
+
+// NewRateBudgets creates a new RateBudgets sharing the given client.
+// Closing the shared client remains the responsibility of the caller.
+func NewRateBudgets(c RPC) *RateBudgets {
+	return &RateBudgets{
+		c:        c,
+		limiters: make(map[string]*rate.Limiter),
+	}
+}
+
+// Client returns an RPC client for the named subsystem, limited to the given rate and burst.
+// Clients requested for the same subsystem share a single budget; the limit and burst of the first request apply.
+// Closing the returned client does not close the shared client.
+func (b *RateBudgets) Client(subsystem string, limit rate.Limit, burst int) RPC {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	rl, ok := b.limiters[subsystem]
+	if !ok {
+		rl = rate.NewLimiter(limit, burst)
+		b.limiters[subsystem] = rl
+	}
+	return &RateLimitingClient{c: noCloseRPC{b.c}, rl: rl}
+}
+
+// noCloseRPC prevents a shared RPC from being closed by one of its users.
+type noCloseRPC struct {
+	RPC
+}
+
+func (noCloseRPC) Close() {}
@@ -0,0 +1,112 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+)
+
+type jsonRPCError struct {
+	code int
+	msg  string
+}
+
+func (e *jsonRPCError) Error() string  { return e.msg }
+func (e *jsonRPCError) ErrorCode() int { return e.code }
+
+type stubRPC struct {
+	result string
+	err    error
+	calls  int
+	closed bool
+}
+
+func (s *stubRPC) Close() {
+	s.closed = true
+}
+
+func (s *stubRPC) CallContext(ctx context.Context, result any, method string, args ...any) error {
+	s.calls++
+	if s.err != nil {
+		return s.err
+	}
+	return json.Unmarshal([]byte(s.result), result)
+}
+
+func (s *stubRPC) BatchCallContext(ctx context.Context, b []rpc.BatchElem) error {
+	s.calls++
+	return s.err
+}
+
+func (s *stubRPC) EthSubscribe(ctx context.Context, channel any, args ...any) (ethereum.Subscription, error) {
+	s.calls++
+	return nil, s.err
+}
+
+func TestIsRateLimitError(t *testing.T) {
+	require.False(t, IsRateLimitError(nil))
+	require.False(t, IsRateLimitError(errors.New("connection refused")))
+	require.False(t, IsRateLimitError(rpc.HTTPError{StatusCode: http.StatusInternalServerError}))
+	require.False(t, IsRateLimitError(&jsonRPCError{code: 3, msg: "execution reverted"}))
+	require.True(t, IsRateLimitError(rpc.HTTPError{StatusCode: http.StatusTooManyRequests}))
+	require.True(t, IsRateLimitError(&jsonRPCError{code: limitExceededErrorCode, msg: "limit exceeded"}))
+}
+
+func TestAdaptiveRateLimitingClient(t *testing.T) {
+	stub := &stubRPC{result: `"0x1"`}
+	c := NewAdaptiveRateLimitingClient(stub, rate.Limit(1000), 10)
+	ctx := context.Background()
+	var res string
+
+	require.NoError(t, c.CallContext(ctx, &res, "eth_getLogs"))
+	require.Equal(t, rate.Limit(1000), c.Limit("eth_getLogs"))
+
+	stub.err = rpc.HTTPError{StatusCode: http.StatusTooManyRequests}
+	require.Error(t, c.CallContext(ctx, &res, "eth_getLogs"))
+	require.Equal(t, rate.Limit(500), c.Limit("eth_getLogs"))
+	require.Equal(t, rate.Limit(1000), c.Limit("eth_call"), "other methods are unaffected")
+
+	// The limit never drops below a 64th of the maximum.
+	for i := 0; i < 10; i++ {
+		_ = c.CallContext(ctx, &res, "eth_getLogs")
+	}
+	require.Equal(t, rate.Limit(1000)/64, c.Limit("eth_getLogs"))
+
+	// Other errors neither lower nor raise the limit.
+	stub.err = errors.New("connection refused")
+	require.Error(t, c.CallContext(ctx, &res, "eth_getLogs"))
+	require.Equal(t, rate.Limit(1000)/64, c.Limit("eth_getLogs"))
+
+	// Successful requests recover the limit step by step, up to the maximum.
+	stub.err = nil
+	require.NoError(t, c.CallContext(ctx, &res, "eth_getLogs"))
+	require.Equal(t, rate.Limit(1000)/64+rate.Limit(50), c.Limit("eth_getLogs"))
+	for i := 0; i < 25; i++ {
+		require.NoError(t, c.CallContext(ctx, &res, "eth_getLogs"))
+	}
+	require.Equal(t, rate.Limit(1000), c.Limit("eth_getLogs"))
+}
+
+func TestRateBudgets(t *testing.T) {
+	stub := &stubRPC{result: `"0x1"`}
+	budgets := NewRateBudgets(stub)
+	discovery := budgets.Client("discovery", rate.Limit(1), 1)
+	trace := budgets.Client("trace", rate.Limit(1), 1)
+	ctx := context.Background()
+	var res string
+
+	require.NoError(t, discovery.CallContext(ctx, &res, "eth_call"))
+	// The discovery budget is exhausted, but the trace budget is independent.
+	require.NoError(t, trace.CallContext(ctx, &res, "eth_call"))
+	require.Equal(t, 2, stub.calls)
+
+	discovery.Close()
+	require.False(t, stub.closed, "closing a budgeted client must not close the shared client")
+}
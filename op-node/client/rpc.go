@@ -31,6 +31,7 @@ type rpcConfig struct {
 	backoffAttempts  int
 	limit            float64
 	burst            int
+	adaptiveLimit    bool
 }
 
 type RPCOption func(cfg *rpcConfig) error
@@ -70,6 +71,18 @@ func WithRateLimit(rateLimit float64, burst int) RPCOption {
 	}
 }
 
+// WithAdaptiveRateLimit configures the RPC to rate-limit each method separately, starting at
+// the given limit (in requests / second) and backing off when the provider reports rate limiting.
+// See NewAdaptiveRateLimitingClient for more details.
+func WithAdaptiveRateLimit(rateLimit float64, burst int) RPCOption {
+	return func(cfg *rpcConfig) error {
+		cfg.limit = rateLimit
+		cfg.burst = burst
+		cfg.adaptiveLimit = true
+		return nil
+	}
+}
+
 // NewRPC returns the correct client.RPC instance for a given RPC url.
 func NewRPC(ctx context.Context, lgr log.Logger, addr string, opts ...RPCOption) (RPC, error) {
 	var cfg rpcConfig
@@ -88,7 +101,9 @@ func NewRPC(ctx context.Context, lgr log.Logger, addr string, opts ...RPCOption)
 
 	var wrapped RPC = &BaseRPCClient{c: underlying}
 
-	if cfg.limit != 0 {
+	if cfg.limit != 0 && cfg.adaptiveLimit {
+		wrapped = NewAdaptiveRateLimitingClient(wrapped, rate.Limit(cfg.limit), cfg.burst)
+	} else if cfg.limit != 0 {
 		wrapped = NewRateLimitingClient(wrapped, rate.Limit(cfg.limit), cfg.burst)
 	}
 
@@ -96,6 +111,9 @@ func NewRPC(ctx context.Context, lgr log.Logger, addr string, opts ...RPCOption)
 		wrapped = NewPollingClient(ctx, lgr, wrapped, WithPollRate(cfg.httpPollInterval))
 	}
 
+	// Outermost, so traced durations include time spent waiting on rate limits.
+	wrapped = NewTracingRPC(wrapped)
+
 	return wrapped, nil
 }
 
@@ -0,0 +1,154 @@
+package client
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/rpc"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	oteltrace "go.opentelemetry.io/otel/trace"
+
+	"github.com/ethereum-optimism/optimism/op-node/metrics"
+)
+
+// tracerName is the name of the OpenTelemetry tracer that creates the spans of RPC requests.
+const tracerName = "github.com/ethereum-optimism/optimism/op-node/client"
+
+// StartSpan starts an OpenTelemetry span for a unit of work, e.g. one solver tick, with the global
+// tracer provider. The spans of RPC requests made with the returned context are children of this span.
+// Spans are only exported if a tracer provider was installed with otel.SetTracerProvider.
+func StartSpan(ctx context.Context, name string) (context.Context, oteltrace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name)
+}
+
+// TracedCall describes a single RPC request made while a CallTrace was attached to the context.
+type TracedCall struct {
+	Method   string
+	Start    time.Time
+	Duration time.Duration
+	Err      error
+}
+
+// CallTrace collects the RPC requests made on behalf of a single unit of work,
+// e.g. one solver tick, so that the time spent in that unit of work can be attributed to specific calls.
+type CallTrace struct {
+	mtx   sync.Mutex
+	calls []TracedCall
+}
+
+type callTraceKey struct{}
+
+// WithCallTrace returns a child context carrying a new CallTrace.
+// Requests made with the returned context through a TracingRPCClient are recorded in the trace.
+func WithCallTrace(ctx context.Context) (context.Context, *CallTrace) {
+	trace := new(CallTrace)
+	return context.WithValue(ctx, callTraceKey{}, trace), trace
+}
+
+// CallTraceFromContext returns the CallTrace attached to the context, or nil if there is none.
+func CallTraceFromContext(ctx context.Context) *CallTrace {
+	trace, _ := ctx.Value(callTraceKey{}).(*CallTrace)
+	return trace
+}
+
+func (t *CallTrace) record(method string, start time.Time, err error) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	t.calls = append(t.calls, TracedCall{
+		Method:   method,
+		Start:    start,
+		Duration: time.Since(start),
+		Err:      err,
+	})
+}
+
+// Calls returns a copy of the requests recorded so far, in order of completion.
+func (t *CallTrace) Calls() []TracedCall {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	return append([]TracedCall(nil), t.calls...)
+}
+
+// Total returns the number of recorded requests and their combined duration.
+func (t *CallTrace) Total() (count int, duration time.Duration) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	for _, c := range t.calls {
+		duration += c.Duration
+	}
+	return len(t.calls), duration
+}
+
+// Slowest returns the slowest recorded request, and false if no requests were recorded.
+func (t *CallTrace) Slowest() (TracedCall, bool) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	var slowest TracedCall
+	for _, c := range t.calls {
+		if c.Duration >= slowest.Duration {
+			slowest = c
+		}
+	}
+	return slowest, len(t.calls) > 0
+}
+
+// TracingRPCClient is an RPC client that records each request in the CallTrace
+// attached to the request context, if any, and creates an OpenTelemetry span for each request.
+// Spans are only exported if a tracer provider was installed with otel.SetTracerProvider.
+type TracingRPCClient struct {
+	c      RPC
+	tracer oteltrace.Tracer
+}
+
+// NewTracingRPC creates a new tracing RPC client.
+func NewTracingRPC(c RPC) *TracingRPCClient {
+	return &TracingRPCClient{c: c, tracer: otel.Tracer(tracerName)}
+}
+
+// startSpan starts the client span of an RPC request.
+func (tc *TracingRPCClient) startSpan(ctx context.Context, method string, attrs ...attribute.KeyValue) (context.Context, oteltrace.Span) {
+	attrs = append(attrs, attribute.String("rpc.method", method))
+	return tc.tracer.Start(ctx, method, oteltrace.WithSpanKind(oteltrace.SpanKindClient), oteltrace.WithAttributes(attrs...))
+}
+
+func endSpan(span oteltrace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+func (tc *TracingRPCClient) Close() {
+	tc.c.Close()
+}
+
+func (tc *TracingRPCClient) CallContext(ctx context.Context, result any, method string, args ...any) error {
+	ctx, span := tc.startSpan(ctx, method)
+	start := time.Now()
+	err := tc.c.CallContext(ctx, result, method, args...)
+	endSpan(span, err)
+	if trace := CallTraceFromContext(ctx); trace != nil {
+		trace.record(method, start, err)
+	}
+	return err
+}
+
+func (tc *TracingRPCClient) BatchCallContext(ctx context.Context, b []rpc.BatchElem) error {
+	ctx, span := tc.startSpan(ctx, metrics.BatchMethod, attribute.Int("rpc.batch_size", len(b)))
+	start := time.Now()
+	err := tc.c.BatchCallContext(ctx, b)
+	endSpan(span, err)
+	if trace := CallTraceFromContext(ctx); trace != nil {
+		trace.record(metrics.BatchMethod, start, err)
+	}
+	return err
+}
+
+func (tc *TracingRPCClient) EthSubscribe(ctx context.Context, channel any, args ...any) (ethereum.Subscription, error) {
+	return tc.c.EthSubscribe(ctx, channel, args...)
+}
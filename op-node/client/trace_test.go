@@ -0,0 +1,93 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	oteltrace "go.opentelemetry.io/otel/trace"
+
+	"github.com/ethereum-optimism/optimism/op-node/metrics"
+)
+
+func TestTracingRPCClient(t *testing.T) {
+	stub := &stubRPC{result: `"0x1"`}
+	c := NewTracingRPC(stub)
+
+	t.Run("NoTrace", func(t *testing.T) {
+		var res string
+		require.NoError(t, c.CallContext(context.Background(), &res, "eth_chainId"))
+		require.Equal(t, "0x1", res)
+	})
+
+	t.Run("RecordsCalls", func(t *testing.T) {
+		ctx, trace := WithCallTrace(context.Background())
+		require.Same(t, trace, CallTraceFromContext(ctx))
+
+		var res string
+		require.NoError(t, c.CallContext(ctx, &res, "eth_chainId"))
+		stub.err = errors.New("boom")
+		require.Error(t, c.BatchCallContext(ctx, []rpc.BatchElem{{Method: "eth_getBlockByNumber"}}))
+
+		calls := trace.Calls()
+		require.Len(t, calls, 2)
+		require.Equal(t, "eth_chainId", calls[0].Method)
+		require.NoError(t, calls[0].Err)
+		require.Equal(t, metrics.BatchMethod, calls[1].Method)
+		require.ErrorIs(t, calls[1].Err, stub.err)
+
+		count, _ := trace.Total()
+		require.Equal(t, 2, count)
+		_, ok := trace.Slowest()
+		require.True(t, ok)
+	})
+}
+
+// recordingTracer is an OpenTelemetry tracer that keeps the spans it started.
+type recordingTracer struct {
+	spans []*recordingSpan
+}
+
+func (r *recordingTracer) Start(ctx context.Context, name string, _ ...oteltrace.SpanStartOption) (context.Context, oteltrace.Span) {
+	span := &recordingSpan{Span: oteltrace.SpanFromContext(ctx), name: name}
+	r.spans = append(r.spans, span)
+	return ctx, span
+}
+
+type recordingSpan struct {
+	oteltrace.Span
+	name   string
+	status codes.Code
+	ended  bool
+}
+
+func (s *recordingSpan) SetStatus(code codes.Code, _ string)         { s.status = code }
+func (s *recordingSpan) RecordError(error, ...oteltrace.EventOption) {}
+func (s *recordingSpan) End(...oteltrace.SpanEndOption)              { s.ended = true }
+
+func TestTracingRPCClient_Spans(t *testing.T) {
+	stub := &stubRPC{result: `"0x1"`}
+	tracer := new(recordingTracer)
+	c := NewTracingRPC(stub)
+	c.tracer = tracer
+
+	var res string
+	require.NoError(t, c.CallContext(context.Background(), &res, "eth_chainId"))
+	stub.err = errors.New("boom")
+	require.Error(t, c.BatchCallContext(context.Background(), []rpc.BatchElem{{Method: "eth_getBlockByNumber"}}))
+
+	require.Len(t, tracer.spans, 2)
+	require.Equal(t, "eth_chainId", tracer.spans[0].name)
+	require.Equal(t, codes.Unset, tracer.spans[0].status)
+	require.True(t, tracer.spans[0].ended)
+	require.Equal(t, metrics.BatchMethod, tracer.spans[1].name)
+	require.Equal(t, codes.Error, tracer.spans[1].status)
+	require.True(t, tracer.spans[1].ended)
+}
+
+func TestCallTraceFromContext_Missing(t *testing.T) {
+	require.Nil(t, CallTraceFromContext(context.Background()))
+}
@@ -88,6 +88,11 @@ var (
 		EnvVars: prefixEnvVars("L1_RPC_RATE_LIMIT"),
 		Value:   0,
 	}
+	L1RPCAdaptiveRateLimit = &cli.BoolFlag{
+		Name:    "l1.rpc-adaptive-rate-limit",
+		Usage:   "Apply the L1 RPC rate-limit to each RPC method separately, and lower it while the L1 RPC reports rate limiting. Only used if l1.rpc-rate-limit is set.",
+		EnvVars: prefixEnvVars("L1_RPC_ADAPTIVE_RATE_LIMIT"),
+	}
 	L1RPCMaxBatchSize = &cli.IntFlag{
 		Name:    "l1.rpc-max-batch-size",
 		Usage:   "Maximum number of RPC requests to bundle, e.g. during L1 blocks receipt fetching. The L1 RPC rate limit counts this as N items, but allows it to burst at once.",
@@ -229,6 +234,7 @@ var optionalFlags = []cli.Flag{
 	L1TrustRPC,
 	L1RPCProviderKind,
 	L1RPCRateLimit,
+	L1RPCAdaptiveRateLimit,
 	L1RPCMaxBatchSize,
 	L1HTTPPollInterval,
 	L2EngineJWTSecret,
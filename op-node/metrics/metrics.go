@@ -42,6 +42,8 @@ type Metricer interface {
 	RecordRPCServerRequest(method string) func()
 	RecordRPCClientRequest(method string) func(err error)
 	RecordRPCClientResponse(method string, err error)
+	RecordRPCEndpointStats(endpoint int, p50, p90, p99 time.Duration, errorRate float64)
+	RecordRPCEndpointDemoted(endpoint int, demoted bool)
 	SetDerivationIdle(status bool)
 	RecordPipelineReset()
 	RecordSequencingError()
@@ -87,6 +89,9 @@ type Metrics struct {
 	RPCClientRequestsTotal          *prometheus.CounterVec
 	RPCClientRequestDurationSeconds *prometheus.HistogramVec
 	RPCClientResponsesTotal         *prometheus.CounterVec
+	RPCClientEndpointLatency        *prometheus.GaugeVec
+	RPCClientEndpointErrorRate      *prometheus.GaugeVec
+	RPCClientEndpointDemoted        *prometheus.GaugeVec
 
 	L1SourceCache *CacheMetrics
 	L2SourceCache *CacheMetrics
@@ -220,6 +225,31 @@ func NewMetrics(procName string) *Metrics {
 			"method",
 			"error",
 		}),
+		RPCClientEndpointLatency: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: ns,
+			Subsystem: RPCClientSubsystem,
+			Name:      "endpoint_latency_seconds",
+			Help:      "Latency percentiles of recent successful requests per fallback RPC endpoint",
+		}, []string{
+			"endpoint",
+			"quantile",
+		}),
+		RPCClientEndpointErrorRate: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: ns,
+			Subsystem: RPCClientSubsystem,
+			Name:      "endpoint_error_rate",
+			Help:      "Fraction of recent requests per fallback RPC endpoint that failed with a transport error",
+		}, []string{
+			"endpoint",
+		}),
+		RPCClientEndpointDemoted: factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: ns,
+			Subsystem: RPCClientSubsystem,
+			Name:      "endpoint_demoted",
+			Help:      "1 if the fallback RPC endpoint is demoted for being too slow or failing too often",
+		}, []string{
+			"endpoint",
+		}),
 
 		L1SourceCache: NewCacheMetrics(factory, ns, "l1_source_cache", "L1 Source cache"),
 		L2SourceCache: NewCacheMetrics(factory, ns, "l2_source_cache", "L2 Source cache"),
@@ -522,6 +552,24 @@ func (m *Metrics) RecordRPCClientResponse(method string, err error) {
 	m.RPCClientResponsesTotal.WithLabelValues(method, errStr).Inc()
 }
 
+// RecordRPCEndpointStats records the latency percentiles and error rate
+// of the recent requests to a fallback RPC endpoint.
+func (m *Metrics) RecordRPCEndpointStats(endpoint int, p50, p90, p99 time.Duration, errorRate float64) {
+	label := strconv.Itoa(endpoint)
+	m.RPCClientEndpointLatency.WithLabelValues(label, "0.5").Set(p50.Seconds())
+	m.RPCClientEndpointLatency.WithLabelValues(label, "0.9").Set(p90.Seconds())
+	m.RPCClientEndpointLatency.WithLabelValues(label, "0.99").Set(p99.Seconds())
+	m.RPCClientEndpointErrorRate.WithLabelValues(label).Set(errorRate)
+}
+
+func (m *Metrics) RecordRPCEndpointDemoted(endpoint int, demoted bool) {
+	var val float64
+	if demoted {
+		val = 1
+	}
+	m.RPCClientEndpointDemoted.WithLabelValues(strconv.Itoa(endpoint)).Set(val)
+}
+
 func (m *Metrics) SetDerivationIdle(status bool) {
 	var val float64
 	if status {
@@ -745,6 +793,12 @@ func (n *noopMetricer) RecordRPCClientRequest(method string) func(err error) {
 func (n *noopMetricer) RecordRPCClientResponse(method string, err error) {
 }
 
+func (n *noopMetricer) RecordRPCEndpointStats(endpoint int, p50, p90, p99 time.Duration, errorRate float64) {
+}
+
+func (n *noopMetricer) RecordRPCEndpointDemoted(endpoint int, demoted bool) {
+}
+
 func (n *noopMetricer) SetDerivationIdle(status bool) {
 }
 
@@ -144,6 +144,10 @@ type L1EndpointConfig struct {
 	// RateLimit specifies a self-imposed rate-limit on L1 requests. 0 is no rate-limit.
 	RateLimit float64
 
+	// AdaptiveRateLimit applies the rate-limit to each L1 RPC method separately, and lowers it
+	// while the L1 RPC reports rate limiting. Only used if RateLimit is set.
+	AdaptiveRateLimit bool
+
 	// BatchSize specifies the maximum batch-size, which also applies as L1 rate-limit burst amount (if set).
 	BatchSize int
 
@@ -171,7 +175,9 @@ func (cfg *L1EndpointConfig) Setup(ctx context.Context, log log.Logger, rollupCf
 		client.WithHttpPollInterval(cfg.HttpPollInterval),
 		client.WithDialBackoff(10),
 	}
-	if cfg.RateLimit != 0 {
+	if cfg.RateLimit != 0 && cfg.AdaptiveRateLimit {
+		opts = append(opts, client.WithAdaptiveRateLimit(cfg.RateLimit, cfg.BatchSize))
+	} else if cfg.RateLimit != 0 {
 		opts = append(opts, client.WithRateLimit(cfg.RateLimit, cfg.BatchSize))
 	}
 
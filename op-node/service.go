@@ -102,12 +102,13 @@ func NewConfig(ctx *cli.Context, log log.Logger) (*node.Config, error) {
 
 func NewL1EndpointConfig(ctx *cli.Context) *node.L1EndpointConfig {
 	return &node.L1EndpointConfig{
-		L1NodeAddr:       ctx.String(flags.L1NodeAddr.Name),
-		L1TrustRPC:       ctx.Bool(flags.L1TrustRPC.Name),
-		L1RPCKind:        sources.RPCProviderKind(strings.ToLower(ctx.String(flags.L1RPCProviderKind.Name))),
-		RateLimit:        ctx.Float64(flags.L1RPCRateLimit.Name),
-		BatchSize:        ctx.Int(flags.L1RPCMaxBatchSize.Name),
-		HttpPollInterval: ctx.Duration(flags.L1HTTPPollInterval.Name),
+		L1NodeAddr:        ctx.String(flags.L1NodeAddr.Name),
+		L1TrustRPC:        ctx.Bool(flags.L1TrustRPC.Name),
+		L1RPCKind:         sources.RPCProviderKind(strings.ToLower(ctx.String(flags.L1RPCProviderKind.Name))),
+		RateLimit:         ctx.Float64(flags.L1RPCRateLimit.Name),
+		AdaptiveRateLimit: ctx.Bool(flags.L1RPCAdaptiveRateLimit.Name),
+		BatchSize:         ctx.Int(flags.L1RPCMaxBatchSize.Name),
+		HttpPollInterval:  ctx.Duration(flags.L1HTTPPollInterval.Name),
 	}
 }
 
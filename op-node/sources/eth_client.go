@@ -12,6 +12,7 @@ package sources
 import (
 	"context"
 	"fmt"
+	"io"
 	"math/big"
 	"time"
 
@@ -20,6 +21,7 @@ import (
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
 
 	"github.com/ethereum-optimism/optimism/op-node/client"
 	"github.com/ethereum-optimism/optimism/op-node/eth"
@@ -307,6 +309,65 @@ func (s *EthClient) InfoByLabel(ctx context.Context, label eth.BlockLabel) (eth.
 	return s.headerCall(ctx, "eth_getBlockByNumber", label)
 }
 
+// MaxInfosRange is the maximum number of headers InfosByRange fetches in a single call.
+const MaxInfosRange = 1000
+
+// InfosByRange fetches the headers of all blocks from start up to and including end, using batched requests.
+// The headers are verified to form a single chain, so a reorg during fetching results in an error
+// rather than a mix of blocks from different chains. All fetched headers are added to the header cache.
+// At most MaxInfosRange headers are fetched at once, larger ranges result in an error.
+func (s *EthClient) InfosByRange(ctx context.Context, start uint64, end uint64) ([]eth.BlockInfo, error) {
+	if end < start {
+		return nil, fmt.Errorf("invalid block range: start %d is after end %d", start, end)
+	}
+	if end-start >= MaxInfosRange {
+		return nil, fmt.Errorf("block range %d to %d exceeds the maximum of %d blocks", start, end, MaxInfosRange)
+	}
+	ids := make([]numberID, 0, end-start+1)
+	// count up from start without ever incrementing past end, which may be the max uint64
+	for n := start; ; n++ {
+		ids = append(ids, numberID(n))
+		if n == end {
+			break
+		}
+	}
+	results, err := fetchBatched(ctx, s, ids, makeHeaderRequest)
+	if err != nil {
+		return nil, err
+	}
+	infos := make([]eth.BlockInfo, len(results))
+	for i, res := range results {
+		header := *res
+		if header == nil {
+			return nil, fmt.Errorf("block %d: %w", uint64(ids[i]), ethereum.NotFound)
+		}
+		info, err := header.Info(s.trustRPC, s.mustBePostMerge)
+		if err != nil {
+			return nil, err
+		}
+		if err := ids[i].CheckID(eth.ToBlockID(info)); err != nil {
+			return nil, fmt.Errorf("fetched block header does not match requested ID: %w", err)
+		}
+		if i > 0 && info.ParentHash() != infos[i-1].Hash() {
+			return nil, fmt.Errorf("block %s does not build on %s, chain changed while fetching range", eth.ToBlockID(info), eth.ToBlockID(infos[i-1]))
+		}
+		infos[i] = info
+	}
+	for _, info := range infos {
+		s.headersCache.Add(info.Hash(), info)
+	}
+	return infos, nil
+}
+
+func makeHeaderRequest(id numberID) (**rpcHeader, rpc.BatchElem) {
+	out := new(*rpcHeader)
+	return out, rpc.BatchElem{
+		Method: "eth_getBlockByNumber",
+		Args:   []any{id.Arg(), false}, // headers are just blocks without txs
+		Result: out,                    // header may become nil, double pointer is intentional
+	}
+}
+
 func (s *EthClient) InfoAndTxsByHash(ctx context.Context, hash common.Hash) (eth.BlockInfo, types.Transactions, error) {
 	if header, ok := s.headersCache.Get(hash); ok {
 		if txs, ok := s.transactionsCache.Get(hash); ok {
@@ -368,6 +429,107 @@ func (s *EthClient) FetchReceipts(ctx context.Context, blockHash common.Hash) (e
 	return info, receipts, nil
 }
 
+// FetchReceiptsByHashes returns the block infos and receipts of all the given blocks, in the same order.
+// Blocks with receipts in the cache, e.g. from an earlier FetchReceipts call, are served from the cache,
+// so repeated requests for the same recent blocks do not cause additional RPC calls.
+// The other blocks are fetched with batched eth_getBlockByHash calls, and their receipts with batched
+// eth_getBlockReceipts calls. Like FetchReceipts, the receipts are verified against the receipt hash of their block.
+func (s *EthClient) FetchReceiptsByHashes(ctx context.Context, blockHashes []common.Hash) ([]eth.BlockInfo, []types.Receipts, error) {
+	infos := make([]eth.BlockInfo, len(blockHashes))
+	receipts := make([]types.Receipts, len(blockHashes))
+	var missing []hashID
+	var missingIndices []int
+	for i, hash := range blockHashes {
+		if info, ok := s.headersCache.Get(hash); ok {
+			if job, ok := s.receiptsCache.Get(hash); ok {
+				if result := job.(*receiptsFetchingJob).Result(); result != nil {
+					infos[i] = info.(eth.BlockInfo)
+					receipts[i] = result
+					continue
+				}
+			}
+		}
+		missing = append(missing, hashID(blockHashes[i]))
+		missingIndices = append(missingIndices, i)
+	}
+	if len(missing) == 0 {
+		return infos, receipts, nil
+	}
+
+	blocks, err := fetchBatched(ctx, s, missing, makeBlockRequest)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch blocks: %w", err)
+	}
+	blockReceipts, err := fetchBatched(ctx, s, missing, makeBlockReceiptsRequest)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch block receipts: %w", err)
+	}
+	for j, id := range missing {
+		block := *blocks[j]
+		if block == nil {
+			return nil, nil, fmt.Errorf("block %s: %w", common.Hash(id), ethereum.NotFound)
+		}
+		info, txs, err := block.Info(s.trustRPC, s.mustBePostMerge)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := id.CheckID(eth.ToBlockID(info)); err != nil {
+			return nil, nil, fmt.Errorf("fetched block data does not match requested ID: %w", err)
+		}
+		// The receipts of an empty block must still be cached as a completed result.
+		result := *blockReceipts[j]
+		if result == nil {
+			result = types.Receipts{}
+		}
+		if err := validateReceipts(eth.ToBlockID(info), info.ReceiptHash(), eth.TransactionsToHashes(txs), result); err != nil {
+			return nil, nil, fmt.Errorf("invalid receipts of block %s: %w", common.Hash(id), err)
+		}
+		s.headersCache.Add(info.Hash(), info)
+		s.transactionsCache.Add(info.Hash(), txs)
+		s.receiptsCache.Add(info.Hash(), &receiptsFetchingJob{block: eth.ToBlockID(info), receiptHash: info.ReceiptHash(), result: result})
+		infos[missingIndices[j]] = info
+		receipts[missingIndices[j]] = result
+	}
+	return infos, receipts, nil
+}
+
+// fetchBatched fetches the results of all requests with batched calls of the client.
+func fetchBatched[K any, V any](ctx context.Context, s *EthClient, ids []K, makeRequest func(K) (V, rpc.BatchElem)) ([]V, error) {
+	fetcher := NewIterativeBatchCall[K, V](
+		ids,
+		makeRequest,
+		s.client.BatchCallContext,
+		s.client.CallContext,
+		s.maxBatchSize,
+	)
+	for {
+		if err := fetcher.Fetch(ctx); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+	}
+	return fetcher.Result()
+}
+
+func makeBlockRequest(id hashID) (**rpcBlock, rpc.BatchElem) {
+	out := new(*rpcBlock)
+	return out, rpc.BatchElem{
+		Method: "eth_getBlockByHash",
+		Args:   []any{id.Arg(), true},
+		Result: out, // block may become nil, double pointer is intentional
+	}
+}
+
+func makeBlockReceiptsRequest(id hashID) (*types.Receipts, rpc.BatchElem) {
+	out := new(types.Receipts)
+	return out, rpc.BatchElem{
+		Method: "eth_getBlockReceipts",
+		Args:   []any{id.Arg()},
+		Result: out,
+	}
+}
+
 // GetProof returns an account proof result, with any optional requested storage proofs.
 // The retrieval does sanity-check that storage proofs for the expected keys are present in the response,
 // but does not verify the result. Call accountResult.Verify(stateRoot) to verify the result.
@@ -2,6 +2,7 @@ package sources
 
 import (
 	"context"
+	"math"
 	"math/big"
 	"math/rand"
 	"testing"
@@ -177,3 +178,176 @@ func TestEthClient_WrongInfoByHash(t *testing.T) {
 	require.Error(t, err, "cannot accept the wrong block")
 	m.Mock.AssertExpectations(t)
 }
+
+func randHeaderChain(start uint64, count int) []*rpcHeader {
+	headers := make([]*rpcHeader, count)
+	for i := range headers {
+		_, rhdr := randHeader()
+		rhdr.Number = hexutil.Uint64(start + uint64(i))
+		if i > 0 {
+			rhdr.ParentHash = headers[i-1].Hash
+		}
+		rhdr.Hash = rhdr.computeBlockHash()
+		headers[i] = rhdr
+	}
+	return headers
+}
+
+func mockHeaderBatch(m *mockRPC, ctx context.Context, headers []*rpcHeader) {
+	m.On("BatchCallContext", ctx, mock.Anything).Run(func(args mock.Arguments) {
+		batch := args[1].([]rpc.BatchElem)
+		for i, elem := range batch {
+			*elem.Result.(**rpcHeader) = headers[i]
+		}
+	}).Return([]error{nil}).Once()
+}
+
+func TestEthClient_InfosByRange(t *testing.T) {
+	m := new(mockRPC)
+	headers := randHeaderChain(100, 3)
+	ctx := context.Background()
+	mockHeaderBatch(m, ctx, headers)
+	s, err := NewL1Client(m, nil, nil, L1ClientDefaultConfig(&rollup.Config{SeqWindowSize: 10}, true, RPCKindBasic))
+	require.NoError(t, err)
+	refs, err := s.L1BlockRefsByRange(ctx, 100, 102)
+	require.NoError(t, err)
+	require.Len(t, refs, 3)
+	for i, ref := range refs {
+		require.Equal(t, headers[i].Hash, ref.Hash)
+		require.Equal(t, uint64(100+i), ref.Number)
+	}
+	m.Mock.AssertExpectations(t)
+
+	// The fetched headers are cached by hash.
+	info, err := s.InfoByHash(ctx, headers[1].Hash)
+	require.NoError(t, err)
+	require.Equal(t, headers[1].Hash, info.Hash())
+	m.Mock.AssertExpectations(t)
+}
+
+func TestEthClient_InfosByRangeReorg(t *testing.T) {
+	m := new(mockRPC)
+	headers := randHeaderChain(100, 3)
+	// Replace the last block with one from a different chain.
+	other := randHeaderChain(102, 1)
+	headers[2] = other[0]
+	ctx := context.Background()
+	mockHeaderBatch(m, ctx, headers)
+	s, err := NewEthClient(m, nil, nil, testEthClientConfig)
+	require.NoError(t, err)
+	_, err = s.InfosByRange(ctx, 100, 102)
+	require.ErrorContains(t, err, "chain changed")
+	m.Mock.AssertExpectations(t)
+}
+
+func TestEthClient_InfosByRangeMissingBlock(t *testing.T) {
+	m := new(mockRPC)
+	headers := randHeaderChain(100, 2)
+	headers = append(headers, nil)
+	ctx := context.Background()
+	mockHeaderBatch(m, ctx, headers)
+	s, err := NewEthClient(m, nil, nil, testEthClientConfig)
+	require.NoError(t, err)
+	_, err = s.InfosByRange(ctx, 100, 102)
+	require.ErrorIs(t, err, ethereum.NotFound)
+	m.Mock.AssertExpectations(t)
+}
+
+func TestEthClient_InfosByRangeInvalid(t *testing.T) {
+	s, err := NewEthClient(new(mockRPC), nil, nil, testEthClientConfig)
+	require.NoError(t, err)
+	_, err = s.InfosByRange(context.Background(), 5, 4)
+	require.Error(t, err)
+	_, err = s.InfosByRange(context.Background(), 5, 5+MaxInfosRange)
+	require.ErrorContains(t, err, "exceeds the maximum")
+}
+
+func TestEthClient_InfosByRangeMaxUint64(t *testing.T) {
+	m := new(mockRPC)
+	headers := randHeaderChain(math.MaxUint64-1, 2)
+	ctx := context.Background()
+	mockHeaderBatch(m, ctx, headers)
+	s, err := NewEthClient(m, nil, nil, testEthClientConfig)
+	require.NoError(t, err)
+	infos, err := s.InfosByRange(ctx, math.MaxUint64-1, math.MaxUint64)
+	require.NoError(t, err)
+	require.Len(t, infos, 2)
+	require.Equal(t, uint64(math.MaxUint64), infos[1].NumberU64())
+	m.Mock.AssertExpectations(t)
+}
+
+// mockBlocksBatch serves batched eth_getBlockByHash and eth_getBlockReceipts calls for the given blocks.
+// Blocks without receipts are served as empty blocks.
+func mockBlocksBatch(m *mockRPC, blocks map[common.Hash]*rpcBlock, receipts map[common.Hash]types.Receipts) {
+	m.On("BatchCallContext", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		for _, elem := range args[1].([]rpc.BatchElem) {
+			hash := elem.Args[0].(common.Hash)
+			switch elem.Method {
+			case "eth_getBlockByHash":
+				*elem.Result.(**rpcBlock) = blocks[hash]
+			case "eth_getBlockReceipts":
+				*elem.Result.(*types.Receipts) = receipts[hash]
+			}
+		}
+	}).Return([]error{nil})
+}
+
+func TestEthClient_FetchReceiptsByHashes(t *testing.T) {
+	blocks := make(map[common.Hash]*rpcBlock)
+	var hashes []common.Hash
+	for _, header := range randHeaderChain(100, 3) {
+		// empty blocks, so that they have no receipts
+		header.TxHash = types.EmptyRootHash
+		header.ReceiptHash = types.EmptyRootHash
+		header.Hash = header.computeBlockHash()
+		blocks[header.Hash] = &rpcBlock{rpcHeader: *header}
+		hashes = append(hashes, header.Hash)
+	}
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		m := new(mockRPC)
+		mockBlocksBatch(m, blocks, nil)
+		s, err := NewEthClient(m, nil, nil, testEthClientConfig)
+		require.NoError(t, err)
+		infos, receipts, err := s.FetchReceiptsByHashes(ctx, hashes)
+		require.NoError(t, err)
+		require.Len(t, infos, 3)
+		require.Len(t, receipts, 3)
+		for i, info := range infos {
+			require.Equal(t, hashes[i], info.Hash())
+			require.Empty(t, receipts[i])
+		}
+		// one batch for the blocks, and one for their receipts
+		m.AssertNumberOfCalls(t, "BatchCallContext", 2)
+
+		// The results are cached, for this and the single block methods.
+		infos, _, err = s.FetchReceiptsByHashes(ctx, []common.Hash{hashes[2], hashes[0]})
+		require.NoError(t, err)
+		require.Equal(t, hashes[2], infos[0].Hash())
+		require.Equal(t, hashes[0], infos[1].Hash())
+		info, _, err := s.FetchReceipts(ctx, hashes[1])
+		require.NoError(t, err)
+		require.Equal(t, hashes[1], info.Hash())
+		m.AssertNumberOfCalls(t, "BatchCallContext", 2)
+	})
+
+	t.Run("MissingBlock", func(t *testing.T) {
+		m := new(mockRPC)
+		mockBlocksBatch(m, map[common.Hash]*rpcBlock{hashes[0]: blocks[hashes[0]]}, nil)
+		s, err := NewEthClient(m, nil, nil, testEthClientConfig)
+		require.NoError(t, err)
+		_, _, err = s.FetchReceiptsByHashes(ctx, hashes[:2])
+		require.ErrorIs(t, err, ethereum.NotFound)
+	})
+
+	t.Run("InvalidReceipts", func(t *testing.T) {
+		m := new(mockRPC)
+		// a receipt for an empty block does not match its receipt hash
+		mockBlocksBatch(m, blocks, map[common.Hash]types.Receipts{hashes[1]: {&types.Receipt{}}})
+		s, err := NewEthClient(m, nil, nil, testEthClientConfig)
+		require.NoError(t, err)
+		_, _, err = s.FetchReceiptsByHashes(ctx, hashes)
+		require.ErrorContains(t, err, "invalid receipts")
+	})
+}
@@ -115,3 +115,18 @@ func (s *L1Client) L1BlockRefByHash(ctx context.Context, hash common.Hash) (eth.
 	s.l1BlockRefsCache.Add(ref.Hash, ref)
 	return ref, nil
 }
+
+// L1BlockRefsByRange returns the [eth.L1BlockRef]s of all blocks from start up to and including end,
+// fetched with batched requests. The returned references are guaranteed to form a single chain.
+func (s *L1Client) L1BlockRefsByRange(ctx context.Context, start uint64, end uint64) ([]eth.L1BlockRef, error) {
+	infos, err := s.InfosByRange(ctx, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch headers of blocks %d to %d: %w", start, end, err)
+	}
+	refs := make([]eth.L1BlockRef, len(infos))
+	for i, info := range infos {
+		refs[i] = eth.InfoToL1BlockRef(info)
+		s.l1BlockRefsCache.Add(refs[i].Hash, refs[i])
+	}
+	return refs, nil
+}
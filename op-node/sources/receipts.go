@@ -463,6 +463,13 @@ func (job *receiptsFetchingJob) runAltMethod(ctx context.Context, m ReceiptsFetc
 	}
 }
 
+// Result returns the receipts of the job if it completed, or nil otherwise, without fetching.
+func (job *receiptsFetchingJob) Result() types.Receipts {
+	job.m.Lock()
+	defer job.m.Unlock()
+	return job.result
+}
+
 // Fetch makes the job fetch the receipts, and returns the results, if any.
 // An error may be returned if the fetching is not successfully completed,
 // and fetching may be continued/re-attempted by calling Fetch again.
@@ -58,3 +58,7 @@ func (r *RollupClient) SequencerActive(ctx context.Context) (bool, error) {
 	err := r.rpc.CallContext(ctx, &result, "admin_sequencerActive")
 	return result, err
 }
+
+func (r *RollupClient) Close() {
+	r.rpc.Close()
+}
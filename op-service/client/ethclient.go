@@ -2,9 +2,13 @@ package client
 
 import (
 	"context"
+	"net/http"
 	"time"
 
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/ethereum-optimism/optimism/op-node/client"
 )
 
 // DialEthClientWithTimeout attempts to dial the L1 provider using the provided
@@ -16,3 +20,14 @@ func DialEthClientWithTimeout(ctx context.Context, url string, timeout time.Dura
 
 	return ethclient.DialContext(ctxt, url)
 }
+
+// NewEthClient returns an ethclient that sends all requests through the given RPC client,
+// such as a [FallbackClient] or a client of [client.RateBudgets].
+// The ethclient does not support subscriptions. Closing it does not close the RPC client.
+func NewEthClient(ctx context.Context, c client.RPC) (*ethclient.Client, error) {
+	rpcClient, err := rpc.DialOptions(ctx, transportURL, rpc.WithHTTPClient(&http.Client{Transport: &rpcTransport{client: c}}))
+	if err != nil {
+		return nil, err
+	}
+	return ethclient.NewClient(rpcClient), nil
+}
@@ -0,0 +1,499 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/ethereum-optimism/optimism/op-node/client"
+	"github.com/ethereum-optimism/optimism/op-node/metrics"
+)
+
+var (
+	ErrNoEndpoints       = errors.New("no rpc endpoints available")
+	ErrEndpointsDisagree = errors.New("rpc endpoints returned different results")
+)
+
+// FallbackClient is an RPC client that spreads requests over a prioritized list of endpoints.
+// Requests are sent to the currently active endpoint. When it fails with a transport-level error
+// (connection refused, timeout, non-2xx HTTP status) the request is retried against the next
+// endpoint, which then becomes the active one.
+// JSON-RPC errors returned by a node are passed through without failing over,
+// since any other healthy node is expected to return the same error.
+//
+// The latency and transport error rate of recent requests are tracked per endpoint, and evaluated
+// on every health check. With WithDemotion, endpoints that degrade beyond the thresholds are demoted:
+// they are only used once every other endpoint failed, until a health check finds that they recovered.
+type FallbackClient struct {
+	clients []client.RPC
+	lgr     log.Logger
+	metrics FallbackMetricer
+
+	// active is the index of the endpoint requests are currently sent to.
+	active int
+	mtx    sync.RWMutex
+	// stats holds the recent requests of every endpoint, by index. Each endpoint has its own lock,
+	// so that requests to different endpoints do not contend.
+	stats []*endpointStats
+
+	checkInterval    time.Duration
+	consensusMethods map[string]struct{}
+	maxLatency       time.Duration
+	maxErrorRate     float64
+
+	ctx      context.Context
+	cancel   context.CancelFunc
+	closedCh chan struct{}
+}
+
+// statsWindow is the number of recent requests per endpoint that latency and error rate are computed over.
+const statsWindow = 100
+
+// minDemotionSamples is the number of requests an endpoint must have served before it can be demoted,
+// so that a single slow or failed request does not demote it.
+const minDemotionSamples = 20
+
+// FallbackMetricer records the health of the endpoints of a FallbackClient.
+type FallbackMetricer interface {
+	RecordRPCEndpointStats(endpoint int, p50, p90, p99 time.Duration, errorRate float64)
+	RecordRPCEndpointDemoted(endpoint int, demoted bool)
+}
+
+type FallbackClientOption func(f *FallbackClient)
+
+// WithHealthCheckInterval specifies how often endpoints with a higher priority than the active one
+// are checked for recovery. Once a preferred endpoint is reachable again, requests switch back to it.
+// The stats of the endpoints are recorded and checked for demotion at the same interval.
+// Setting this to zero disables health checks, which is useful for testing.
+func WithHealthCheckInterval(interval time.Duration) FallbackClientOption {
+	return func(f *FallbackClient) {
+		f.checkInterval = interval
+	}
+}
+
+// WithConsensusMethods specifies methods that are critical enough to be sent to every endpoint.
+// The results are compared and ErrEndpointsDisagree is returned if any two endpoints disagree.
+func WithConsensusMethods(methods ...string) FallbackClientOption {
+	return func(f *FallbackClient) {
+		for _, method := range methods {
+			f.consensusMethods[method] = struct{}{}
+		}
+	}
+}
+
+// WithDemotion enables demotion of endpoints whose 90th percentile latency exceeds maxLatency,
+// or whose fraction of requests failing with a transport-level error exceeds maxErrorRate.
+// A zero threshold disables that check. Demoted endpoints are promoted again by the health check
+// once they respond within maxLatency.
+func WithDemotion(maxLatency time.Duration, maxErrorRate float64) FallbackClientOption {
+	return func(f *FallbackClient) {
+		f.maxLatency = maxLatency
+		f.maxErrorRate = maxErrorRate
+	}
+}
+
+// WithFallbackMetrics specifies where the latency, error rate and demotion of every endpoint are recorded.
+func WithFallbackMetrics(m FallbackMetricer) FallbackClientOption {
+	return func(f *FallbackClient) {
+		f.metrics = m
+	}
+}
+
+// NewFallbackClient returns a new FallbackClient over the given clients, in order of priority.
+// Canceling the passed-in context stops health checks. Callers are responsible for closing the
+// client, which also closes all underlying clients.
+func NewFallbackClient(ctx context.Context, lgr log.Logger, clients []client.RPC, opts ...FallbackClientOption) *FallbackClient {
+	ctx, cancel := context.WithCancel(ctx)
+	res := &FallbackClient{
+		clients:          clients,
+		lgr:              lgr,
+		metrics:          metrics.NoopMetrics,
+		stats:            make([]*endpointStats, len(clients)),
+		checkInterval:    30 * time.Second,
+		consensusMethods: make(map[string]struct{}),
+		ctx:              ctx,
+		cancel:           cancel,
+		closedCh:         make(chan struct{}),
+	}
+	for i := range res.stats {
+		res.stats[i] = new(endpointStats)
+	}
+	for _, opt := range opts {
+		opt(res)
+	}
+	go res.checkHealth()
+	return res
+}
+
+// NewFallbackRPC dials every address with the given RPC options and returns a FallbackClient
+// over the resulting clients, prioritized in the order the addresses are given.
+func NewFallbackRPC(ctx context.Context, lgr log.Logger, addrs []string, rpcOpts []client.RPCOption, opts ...FallbackClientOption) (*FallbackClient, error) {
+	if len(addrs) == 0 {
+		return nil, ErrNoEndpoints
+	}
+	clients := make([]client.RPC, 0, len(addrs))
+	for i, addr := range addrs {
+		c, err := client.NewRPC(ctx, lgr.New("endpoint", i), addr, rpcOpts...)
+		if err != nil {
+			for _, c := range clients {
+				c.Close()
+			}
+			return nil, fmt.Errorf("failed to dial endpoint %d: %w", i, err)
+		}
+		clients = append(clients, c)
+	}
+	return NewFallbackClient(ctx, lgr, clients, opts...), nil
+}
+
+// Close stops health checks and closes every underlying client.
+func (f *FallbackClient) Close() {
+	f.cancel()
+	<-f.closedCh
+	for _, c := range f.clients {
+		c.Close()
+	}
+}
+
+func (f *FallbackClient) CallContext(ctx context.Context, result any, method string, args ...any) error {
+	if _, ok := f.consensusMethods[method]; ok {
+		return f.consensusCall(ctx, result, method, args...)
+	}
+	return f.withFailover(ctx, method, func(c client.RPC) error {
+		return c.CallContext(ctx, result, method, args...)
+	})
+}
+
+func (f *FallbackClient) BatchCallContext(ctx context.Context, b []rpc.BatchElem) error {
+	return f.withFailover(ctx, metrics.BatchMethod, func(c client.RPC) error {
+		return c.BatchCallContext(ctx, b)
+	})
+}
+
+func (f *FallbackClient) EthSubscribe(ctx context.Context, channel any, args ...any) (ethereum.Subscription, error) {
+	var sub ethereum.Subscription
+	err := f.withFailover(ctx, "eth_subscribe", func(c client.RPC) error {
+		var err error
+		sub, err = c.EthSubscribe(ctx, channel, args...)
+		return err
+	})
+	return sub, err
+}
+
+// withFailover runs fn against the active endpoint, moving on to the following endpoints
+// (wrapping around) for as long as fn fails with a transport-level error.
+// Demoted endpoints are tried last.
+func (f *FallbackClient) withFailover(ctx context.Context, method string, fn func(c client.RPC) error) error {
+	if len(f.clients) == 0 {
+		return ErrNoEndpoints
+	}
+	f.mtx.RLock()
+	start := f.active
+	order := f.failoverOrder(start)
+	f.mtx.RUnlock()
+
+	var err error
+	for _, idx := range order {
+		err = f.call(ctx, idx, fn)
+		if ctx.Err() != nil {
+			return err
+		}
+		if !isTransportError(err) {
+			if idx != start {
+				f.setActive(idx)
+			}
+			return err
+		}
+		f.lgr.Warn("RPC endpoint failed, trying next", "endpoint", idx, "method", method, "err", err)
+	}
+	return fmt.Errorf("all %d rpc endpoints failed: %w", len(f.clients), err)
+}
+
+// consensusCall sends the request to every endpoint and checks that all endpoints that responded
+// agree on the result. Endpoints failing with a transport-level error are skipped.
+func (f *FallbackClient) consensusCall(ctx context.Context, result any, method string, args ...any) error {
+	if len(f.clients) == 0 {
+		return ErrNoEndpoints
+	}
+	var agreed json.RawMessage
+	var agreedValue any
+	var lastErr error
+	responded := false
+	for idx := range f.clients {
+		var raw json.RawMessage
+		err := f.call(ctx, idx, func(c client.RPC) error {
+			return c.CallContext(ctx, &raw, method, args...)
+		})
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if isTransportError(err) {
+			f.lgr.Warn("RPC endpoint failed during consensus call", "endpoint", idx, "method", method, "err", err)
+			lastErr = err
+			continue
+		} else if err != nil {
+			return err
+		}
+		var value any
+		if len(raw) > 0 {
+			if err := json.Unmarshal(raw, &value); err != nil {
+				return fmt.Errorf("endpoint %d returned invalid result: %w", idx, err)
+			}
+		}
+		if !responded {
+			agreed, agreedValue, responded = raw, value, true
+		} else if !reflect.DeepEqual(agreedValue, value) {
+			f.lgr.Error("RPC endpoints disagree", "method", method, "endpoint", idx)
+			return fmt.Errorf("%w: method %s, endpoint %d", ErrEndpointsDisagree, method, idx)
+		}
+	}
+	if !responded {
+		return fmt.Errorf("all %d rpc endpoints failed: %w", len(f.clients), lastErr)
+	}
+	if len(agreed) == 0 {
+		return nil
+	}
+	return json.Unmarshal(agreed, result)
+}
+
+// failoverOrder returns the endpoint indices in the order they are tried, starting at the given
+// endpoint and wrapping around, with demoted endpoints moved to the end.
+// The caller must hold the lock.
+func (f *FallbackClient) failoverOrder(start int) []int {
+	order := make([]int, 0, len(f.clients))
+	var demoted []int
+	for i := 0; i < len(f.clients); i++ {
+		idx := (start + i) % len(f.clients)
+		if f.stats[idx].demoted.Load() {
+			demoted = append(demoted, idx)
+		} else {
+			order = append(order, idx)
+		}
+	}
+	return append(order, demoted...)
+}
+
+// call runs fn against the endpoint and records its latency and whether it failed.
+// Requests aborted by the caller's context are not recorded.
+func (f *FallbackClient) call(ctx context.Context, idx int, fn func(c client.RPC) error) error {
+	start := time.Now()
+	err := fn(f.clients[idx])
+	if ctx.Err() == nil {
+		f.record(idx, time.Since(start), isTransportError(err))
+	}
+	return err
+}
+
+// record adds a request to the stats of the endpoint.
+// It is called for every request, so it only takes the lock of the endpoint and computes nothing.
+func (f *FallbackClient) record(idx int, latency time.Duration, failed bool) {
+	f.stats[idx].add(sample{latency: latency, failed: failed})
+}
+
+// evaluate records the stats of every endpoint and demotes the endpoints
+// that degraded beyond the thresholds.
+func (f *FallbackClient) evaluate() {
+	for idx, s := range f.stats {
+		snap := s.snapshot()
+		f.metrics.RecordRPCEndpointStats(idx, snap.p50, snap.p90, snap.p99, snap.errorRate)
+
+		if s.demoted.Load() || snap.samples < minDemotionSamples {
+			continue
+		}
+		slow := f.maxLatency != 0 && snap.p90 > f.maxLatency
+		failing := f.maxErrorRate != 0 && snap.errorRate > f.maxErrorRate
+		if !slow && !failing {
+			continue
+		}
+		f.lgr.Warn("Demoting degraded RPC endpoint", "endpoint", idx, "p90", snap.p90, "error_rate", snap.errorRate)
+		s.demoted.Store(true)
+		f.metrics.RecordRPCEndpointDemoted(idx, true)
+		f.mtx.Lock()
+		if f.active == idx {
+			if next := f.failoverOrder(idx)[0]; next != idx {
+				f.lgr.Info("Switching active RPC endpoint", "from", f.active, "to", next)
+				f.active = next
+			}
+		}
+		f.mtx.Unlock()
+	}
+}
+
+func (f *FallbackClient) setActive(idx int) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	if f.active != idx {
+		f.lgr.Info("Switching active RPC endpoint", "from", f.active, "to", idx)
+		f.active = idx
+	}
+}
+
+// Active returns the index of the endpoint requests are currently sent to.
+func (f *FallbackClient) Active() int {
+	f.mtx.RLock()
+	defer f.mtx.RUnlock()
+	return f.active
+}
+
+// Demoted returns whether the endpoint at the given index is currently demoted.
+func (f *FallbackClient) Demoted(idx int) bool {
+	return f.stats[idx].demoted.Load()
+}
+
+func (f *FallbackClient) checkHealth() {
+	defer close(f.closedCh)
+	if f.checkInterval == 0 {
+		<-f.ctx.Done()
+		return
+	}
+	ticker := time.NewTicker(f.checkInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			f.evaluate()
+			f.recoverDemoted()
+			f.promote()
+		case <-f.ctx.Done():
+			return
+		}
+	}
+}
+
+// promote switches back to the highest priority endpoint that is reachable again.
+// Demoted endpoints are skipped until they recover.
+func (f *FallbackClient) promote() {
+	active := f.Active()
+	for idx := 0; idx < active; idx++ {
+		if f.Demoted(idx) {
+			continue
+		}
+		if _, err := f.probe(idx); !isTransportError(err) {
+			f.setActive(idx)
+			return
+		}
+	}
+}
+
+// recoverDemoted probes every demoted endpoint and lifts the demotion of the endpoints that respond
+// within the latency threshold. Their stats are reset, so they are judged on new requests only.
+func (f *FallbackClient) recoverDemoted() {
+	for idx := range f.clients {
+		if !f.Demoted(idx) {
+			continue
+		}
+		latency, err := f.probe(idx)
+		if isTransportError(err) || (f.maxLatency != 0 && latency > f.maxLatency) {
+			continue
+		}
+		f.stats[idx].reset()
+		f.lgr.Info("Demoted RPC endpoint recovered", "endpoint", idx, "latency", latency)
+		f.metrics.RecordRPCEndpointDemoted(idx, false)
+	}
+}
+
+// probe sends a cheap request to the endpoint and returns how long it took.
+func (f *FallbackClient) probe(idx int) (time.Duration, error) {
+	ctx, cancel := context.WithTimeout(f.ctx, 5*time.Second)
+	defer cancel()
+	start := time.Now()
+	var raw json.RawMessage
+	err := f.clients[idx].CallContext(ctx, &raw, "eth_chainId")
+	return time.Since(start), err
+}
+
+type sample struct {
+	latency time.Duration
+	failed  bool
+}
+
+// endpointStats holds the most recent requests to an endpoint in a ring buffer.
+type endpointStats struct {
+	mu      sync.Mutex
+	samples []sample
+	next    int
+	demoted atomic.Bool
+}
+
+func (s *endpointStats) add(smp sample) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.samples) < statsWindow {
+		s.samples = append(s.samples, smp)
+		return
+	}
+	s.samples[s.next] = smp
+	s.next = (s.next + 1) % statsWindow
+}
+
+// reset forgets all requests and lifts the demotion.
+func (s *endpointStats) reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.samples = nil
+	s.next = 0
+	s.demoted.Store(false)
+}
+
+// statsSnapshot summarizes the recent requests to an endpoint.
+type statsSnapshot struct {
+	samples       int
+	p50, p90, p99 time.Duration
+	errorRate     float64
+}
+
+// snapshot computes the latency percentiles of the successful requests and the fraction of requests
+// that failed with a transport-level error, from a single sorted copy of the latencies.
+func (s *endpointStats) snapshot() statsSnapshot {
+	s.mu.Lock()
+	latencies := make([]time.Duration, 0, len(s.samples))
+	for _, smp := range s.samples {
+		if !smp.failed {
+			latencies = append(latencies, smp.latency)
+		}
+	}
+	snap := statsSnapshot{samples: len(s.samples)}
+	s.mu.Unlock()
+
+	if snap.samples > 0 {
+		snap.errorRate = float64(snap.samples-len(latencies)) / float64(snap.samples)
+	}
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	snap.p50 = percentile(latencies, 0.5)
+	snap.p90 = percentile(latencies, 0.9)
+	snap.p99 = percentile(latencies, 0.99)
+	return snap
+}
+
+// percentile returns the latency below which the fraction q of the sorted latencies fall,
+// or zero if there are none.
+func percentile(sorted []time.Duration, q float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(math.Ceil(q*float64(len(sorted)))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	return sorted[rank]
+}
+
+// isTransportError returns true if the error was not produced by the node itself,
+// and another endpoint may be able to serve the same request.
+func isTransportError(err error) bool {
+	if err == nil {
+		return false
+	}
+	var rpcErr rpc.Error
+	return !errors.As(err, &rpcErr)
+}
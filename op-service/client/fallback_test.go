@@ -0,0 +1,300 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/client"
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+)
+
+type jsonRPCError struct {
+	code int
+	msg  string
+}
+
+func (e *jsonRPCError) Error() string  { return e.msg }
+func (e *jsonRPCError) ErrorCode() int { return e.code }
+
+type stubRPC struct {
+	result string
+	err    error
+	calls  int
+	closed bool
+}
+
+func (s *stubRPC) Close() {
+	s.closed = true
+}
+
+func (s *stubRPC) CallContext(ctx context.Context, result any, method string, args ...any) error {
+	s.calls++
+	if s.err != nil {
+		return s.err
+	}
+	return json.Unmarshal([]byte(s.result), result)
+}
+
+func (s *stubRPC) BatchCallContext(ctx context.Context, b []rpc.BatchElem) error {
+	s.calls++
+	return s.err
+}
+
+func (s *stubRPC) EthSubscribe(ctx context.Context, channel any, args ...any) (ethereum.Subscription, error) {
+	s.calls++
+	return nil, s.err
+}
+
+func newTestFallbackClient(t *testing.T, clients []client.RPC, opts ...FallbackClientOption) *FallbackClient {
+	opts = append([]FallbackClientOption{WithHealthCheckInterval(0)}, opts...)
+	c := NewFallbackClient(context.Background(), testlog.Logger(t, log.LvlDebug), clients, opts...)
+	t.Cleanup(c.Close)
+	return c
+}
+
+func TestFallbackClient_UsesActiveEndpoint(t *testing.T) {
+	first := &stubRPC{result: `"0x1"`}
+	second := &stubRPC{result: `"0x2"`}
+	c := newTestFallbackClient(t, []client.RPC{first, second})
+
+	var res string
+	require.NoError(t, c.CallContext(context.Background(), &res, "eth_chainId"))
+	require.Equal(t, "0x1", res)
+	require.Equal(t, 1, first.calls)
+	require.Equal(t, 0, second.calls)
+}
+
+func TestFallbackClient_FailsOverOnTransportError(t *testing.T) {
+	first := &stubRPC{err: errors.New("connection refused")}
+	second := &stubRPC{result: `"0x2"`}
+	c := newTestFallbackClient(t, []client.RPC{first, second})
+
+	var res string
+	require.NoError(t, c.CallContext(context.Background(), &res, "eth_chainId"))
+	require.Equal(t, "0x2", res)
+	require.Equal(t, 1, c.Active())
+
+	// Subsequent requests go straight to the new active endpoint.
+	require.NoError(t, c.CallContext(context.Background(), &res, "eth_chainId"))
+	require.Equal(t, 1, first.calls)
+	require.Equal(t, 2, second.calls)
+}
+
+func TestFallbackClient_PassesThroughJSONRPCErrors(t *testing.T) {
+	revert := &jsonRPCError{code: 3, msg: "execution reverted"}
+	first := &stubRPC{err: revert}
+	second := &stubRPC{result: `"0x2"`}
+	c := newTestFallbackClient(t, []client.RPC{first, second})
+
+	var res string
+	err := c.CallContext(context.Background(), &res, "eth_call")
+	require.ErrorIs(t, err, revert)
+	require.Equal(t, 0, c.Active())
+	require.Equal(t, 0, second.calls)
+}
+
+func TestFallbackClient_AllEndpointsFail(t *testing.T) {
+	errDown := errors.New("down")
+	c := newTestFallbackClient(t, []client.RPC{&stubRPC{err: errDown}, &stubRPC{err: errDown}})
+
+	var res string
+	err := c.CallContext(context.Background(), &res, "eth_chainId")
+	require.ErrorIs(t, err, errDown)
+}
+
+func TestFallbackClient_ConsensusMethods(t *testing.T) {
+	t.Run("Agree", func(t *testing.T) {
+		first := &stubRPC{result: `{"hash":"0xaa","number":"0x1"}`}
+		second := &stubRPC{result: `{"number":"0x1","hash":"0xaa"}`}
+		c := newTestFallbackClient(t, []client.RPC{first, second}, WithConsensusMethods("eth_getBlockByHash"))
+
+		var res map[string]string
+		require.NoError(t, c.CallContext(context.Background(), &res, "eth_getBlockByHash"))
+		require.Equal(t, "0xaa", res["hash"])
+		require.Equal(t, 1, first.calls)
+		require.Equal(t, 1, second.calls)
+	})
+
+	t.Run("Disagree", func(t *testing.T) {
+		first := &stubRPC{result: `{"hash":"0xaa"}`}
+		second := &stubRPC{result: `{"hash":"0xbb"}`}
+		c := newTestFallbackClient(t, []client.RPC{first, second}, WithConsensusMethods("eth_getBlockByHash"))
+
+		var res map[string]string
+		err := c.CallContext(context.Background(), &res, "eth_getBlockByHash")
+		require.ErrorIs(t, err, ErrEndpointsDisagree)
+	})
+
+	t.Run("SkipUnreachable", func(t *testing.T) {
+		first := &stubRPC{err: errors.New("timeout")}
+		second := &stubRPC{result: `{"hash":"0xbb"}`}
+		c := newTestFallbackClient(t, []client.RPC{first, second}, WithConsensusMethods("eth_getBlockByHash"))
+
+		var res map[string]string
+		require.NoError(t, c.CallContext(context.Background(), &res, "eth_getBlockByHash"))
+		require.Equal(t, "0xbb", res["hash"])
+	})
+}
+
+func TestFallbackClient_PromotesRecoveredEndpoint(t *testing.T) {
+	first := &stubRPC{err: errors.New("connection refused")}
+	second := &stubRPC{result: `"0x2"`}
+	c := newTestFallbackClient(t, []client.RPC{first, second})
+
+	var res string
+	require.NoError(t, c.CallContext(context.Background(), &res, "eth_chainId"))
+	require.Equal(t, 1, c.Active())
+
+	c.promote()
+	require.Equal(t, 1, c.Active(), "should not promote an endpoint that is still down")
+
+	first.err = nil
+	first.result = `"0x1"`
+	c.promote()
+	require.Equal(t, 0, c.Active())
+}
+
+func TestFallbackClient_CloseClosesAllClients(t *testing.T) {
+	first := &stubRPC{}
+	second := &stubRPC{}
+	c := NewFallbackClient(context.Background(), testlog.Logger(t, log.LvlDebug), []client.RPC{first, second}, WithHealthCheckInterval(0))
+	c.Close()
+	require.True(t, first.closed)
+	require.True(t, second.closed)
+}
+
+func TestFallbackClient_DemotesFailingEndpoint(t *testing.T) {
+	first := &stubRPC{result: `"0x1"`}
+	second := &stubRPC{result: `"0x2"`}
+	c := newTestFallbackClient(t, []client.RPC{first, second}, WithDemotion(0, 0.2))
+
+	var res string
+	for i := 0; i < minDemotionSamples; i++ {
+		require.NoError(t, c.CallContext(context.Background(), &res, "eth_chainId"))
+	}
+	require.False(t, c.Demoted(0))
+
+	// Intermittent failures fail over without demoting, until the error rate exceeds the threshold.
+	for i := 0; i < 10; i++ {
+		first.err = errors.New("connection reset")
+		require.NoError(t, c.CallContext(context.Background(), &res, "eth_chainId"))
+		first.err = nil
+		c.setActive(0)
+	}
+	require.False(t, c.Demoted(0), "stats are only evaluated by the health check")
+	c.evaluate()
+	require.True(t, c.Demoted(0))
+	require.False(t, c.Demoted(1))
+
+	// A demoted endpoint is tried last, even if it is the active one.
+	calls := first.calls
+	require.NoError(t, c.CallContext(context.Background(), &res, "eth_chainId"))
+	require.Equal(t, "0x2", res)
+	require.Equal(t, calls, first.calls)
+	require.Equal(t, 1, c.Active())
+
+	// Health checks do not switch back to a demoted endpoint until it recovered.
+	c.promote()
+	require.Equal(t, 1, c.Active())
+	c.recoverDemoted()
+	require.False(t, c.Demoted(0))
+	c.promote()
+	require.Equal(t, 0, c.Active())
+}
+
+func TestFallbackClient_DemotesSlowEndpoint(t *testing.T) {
+	first := &stubRPC{result: `"0x1"`}
+	second := &stubRPC{result: `"0x2"`}
+	c := newTestFallbackClient(t, []client.RPC{first, second}, WithDemotion(time.Second, 0))
+
+	for i := 0; i < minDemotionSamples-1; i++ {
+		c.record(0, 2*time.Second, false)
+	}
+	c.evaluate()
+	require.False(t, c.Demoted(0), "should not demote before enough samples")
+	c.record(0, 2*time.Second, false)
+	c.evaluate()
+	require.True(t, c.Demoted(0))
+	require.Equal(t, 1, c.Active())
+}
+
+func TestFallbackClient_AllEndpointsDemoted(t *testing.T) {
+	first := &stubRPC{result: `"0x1"`}
+	second := &stubRPC{result: `"0x2"`}
+	c := newTestFallbackClient(t, []client.RPC{first, second}, WithDemotion(time.Second, 0))
+
+	for i := 0; i < minDemotionSamples; i++ {
+		c.record(0, 2*time.Second, false)
+		c.record(1, 2*time.Second, false)
+	}
+	c.evaluate()
+	require.True(t, c.Demoted(0))
+	require.True(t, c.Demoted(1))
+
+	// Demoted endpoints are still used when no other endpoint is left.
+	var res string
+	require.NoError(t, c.CallContext(context.Background(), &res, "eth_chainId"))
+	require.Equal(t, "0x2", res)
+}
+
+func TestEndpointStats(t *testing.T) {
+	s := new(endpointStats)
+	require.Equal(t, statsSnapshot{}, s.snapshot())
+
+	for i := 1; i <= 10; i++ {
+		s.add(sample{latency: time.Duration(i) * time.Millisecond})
+	}
+	s.add(sample{latency: time.Hour, failed: true})
+	snap := s.snapshot()
+	require.Equal(t, 11, snap.samples)
+	require.Equal(t, 5*time.Millisecond, snap.p50)
+	require.Equal(t, 9*time.Millisecond, snap.p90)
+	require.Equal(t, 10*time.Millisecond, snap.p99)
+	require.InDelta(t, 1.0/11, snap.errorRate, 1e-9)
+
+	// Only the most recent requests are kept.
+	for i := 0; i < statsWindow; i++ {
+		s.add(sample{latency: time.Millisecond})
+	}
+	snap = s.snapshot()
+	require.Equal(t, statsWindow, snap.samples)
+	require.Zero(t, snap.errorRate)
+	require.Equal(t, time.Millisecond, snap.p99)
+
+	s.demoted.Store(true)
+	s.reset()
+	require.Equal(t, statsSnapshot{}, s.snapshot())
+	require.False(t, s.demoted.Load())
+}
+
+func TestFallbackEthClient(t *testing.T) {
+	first := &stubRPC{err: errors.New("connection refused")}
+	second := &stubRPC{result: `"0x2"`}
+	ethClient, err := NewEthClient(context.Background(), newTestFallbackClient(t, []client.RPC{first, second}))
+	require.NoError(t, err)
+
+	chainID, err := ethClient.ChainID(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), chainID.Uint64())
+
+	// Errors returned by the node keep their code and data.
+	second.err = &jsonRPCError{code: 3, msg: "execution reverted"}
+	_, err = ethClient.ChainID(context.Background())
+	var rpcErr rpc.Error
+	require.ErrorAs(t, err, &rpcErr)
+	require.Equal(t, 3, rpcErr.ErrorCode())
+	require.Equal(t, "execution reverted", rpcErr.Error())
+
+	// A failure of every endpoint fails the request.
+	second.err = errors.New("connection refused")
+	_, err = ethClient.ChainID(context.Background())
+	require.ErrorContains(t, err, "connection refused")
+}
@@ -0,0 +1,168 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/ethereum-optimism/optimism/op-node/client"
+)
+
+// transportURL is the placeholder URL go-ethereum clients over an [rpcTransport] are dialed with.
+// Requests never leave the process under this URL, they are served by the underlying RPC client.
+const transportURL = "http://rpc.invalid"
+
+// errCodeInternal is the JSON-RPC error code for internal errors.
+const errCodeInternal = -32603
+
+type jsonrpcMessage struct {
+	Version string          `json:"jsonrpc,omitempty"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method,omitempty"`
+	Params  json.RawMessage `json:"params,omitempty"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *jsonError      `json:"error,omitempty"`
+}
+
+type jsonError struct {
+	Code    int         `json:"code"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
+}
+
+// rpcTransport is an [http.RoundTripper] that serves the JSON-RPC requests of a go-ethereum
+// HTTP client with a [client.RPC], so that APIs built on [rpc.Client], such as ethclient and
+// the contract bindings, go through its wrappers, e.g. to fail over between endpoints with a
+// FallbackClient or to share a rate limit.
+// Subscriptions are not supported, as with any HTTP client.
+type rpcTransport struct {
+	client client.RPC
+}
+
+func (t *rpcTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := io.ReadAll(req.Body)
+	_ = req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	var out any
+	if trimmed := bytes.TrimLeft(body, " \t\r\n"); len(trimmed) > 0 && trimmed[0] == '[' {
+		var msgs []*jsonrpcMessage
+		if err := json.Unmarshal(body, &msgs); err != nil {
+			return nil, fmt.Errorf("invalid batch request: %w", err)
+		}
+		if out, err = t.batch(req, msgs); err != nil {
+			return nil, err
+		}
+	} else {
+		var msg jsonrpcMessage
+		if err := json.Unmarshal(body, &msg); err != nil {
+			return nil, fmt.Errorf("invalid request: %w", err)
+		}
+		if out, err = t.call(req, &msg); err != nil {
+			return nil, err
+		}
+	}
+	resp, err := json.Marshal(out)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          io.NopCloser(bytes.NewReader(resp)),
+		ContentLength: int64(len(resp)),
+		Request:       req,
+	}, nil
+}
+
+// call serves a single request. Errors returned by a node are passed back as JSON-RPC errors,
+// while a failure of every endpoint fails the HTTP request.
+func (t *rpcTransport) call(req *http.Request, msg *jsonrpcMessage) (*jsonrpcMessage, error) {
+	args, err := params(msg)
+	if err != nil {
+		return response(msg, nil, &jsonError{Code: errCodeInternal, Message: err.Error()}), nil
+	}
+	var result json.RawMessage
+	err = t.client.CallContext(req.Context(), &result, msg.Method, args...)
+	if isTransportError(err) {
+		return nil, err
+	}
+	return response(msg, result, toJSONError(err)), nil
+}
+
+// batch serves a batch request with a single batch call, which fails over as a whole.
+func (t *rpcTransport) batch(req *http.Request, msgs []*jsonrpcMessage) ([]*jsonrpcMessage, error) {
+	elems := make([]rpc.BatchElem, len(msgs))
+	results := make([]json.RawMessage, len(msgs))
+	for i, msg := range msgs {
+		args, err := params(msg)
+		if err != nil {
+			return nil, err
+		}
+		elems[i] = rpc.BatchElem{Method: msg.Method, Args: args, Result: &results[i]}
+	}
+	if err := t.client.BatchCallContext(req.Context(), elems); err != nil {
+		return nil, err
+	}
+	out := make([]*jsonrpcMessage, len(msgs))
+	for i, msg := range msgs {
+		out[i] = response(msg, results[i], toJSONError(elems[i].Error))
+	}
+	return out, nil
+}
+
+func params(msg *jsonrpcMessage) ([]any, error) {
+	if len(msg.Params) == 0 {
+		return nil, nil
+	}
+	var raw []json.RawMessage
+	if err := json.Unmarshal(msg.Params, &raw); err != nil {
+		return nil, fmt.Errorf("invalid params of %v: %w", msg.Method, err)
+	}
+	args := make([]any, len(raw))
+	for i, arg := range raw {
+		args[i] = arg
+	}
+	return args, nil
+}
+
+func response(req *jsonrpcMessage, result json.RawMessage, err *jsonError) *jsonrpcMessage {
+	msg := &jsonrpcMessage{Version: "2.0", ID: req.ID}
+	if err != nil {
+		msg.Error = err
+		return msg
+	}
+	if len(result) == 0 {
+		result = json.RawMessage("null")
+	}
+	msg.Result = result
+	return msg
+}
+
+// toJSONError converts an error returned by a node back into a JSON-RPC error,
+// keeping its code and data.
+func toJSONError(err error) *jsonError {
+	if err == nil {
+		return nil
+	}
+	out := &jsonError{Code: errCodeInternal, Message: err.Error()}
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) {
+		out.Code = rpcErr.ErrorCode()
+	}
+	var dataErr rpc.DataError
+	if errors.As(err, &dataErr) {
+		out.Data = dataErr.ErrorData()
+	}
+	return out
+}
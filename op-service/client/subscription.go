@@ -0,0 +1,263 @@
+package client
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/backoff"
+)
+
+// blockNumberer is implemented by clients that can report the current chain head,
+// such as *ethclient.Client.
+type blockNumberer interface {
+	BlockNumber(ctx context.Context) (uint64, error)
+}
+
+// trackedBlocks is the number of recent blocks whose hashes a [LogSubscription] remembers to detect reorgs.
+const trackedBlocks = 128
+
+// LogSubscription is an [ethereum.Subscription] delivering the logs matching a filter query.
+// When the underlying subscription fails, e.g. because the websocket connection dropped,
+// it resubscribes with an exponential backoff and backfills the logs missed in the meantime
+// with eth_getLogs, starting from the block of the last delivered log.
+// Logs delivered by both the backfill and the new subscription are only delivered once.
+type LogSubscription struct {
+	client  ethereum.LogFilterer
+	query   ethereum.FilterQuery
+	out     chan<- types.Log
+	log     log.Logger
+	backoff backoff.Strategy
+
+	// fromBlock is where backfilling starts if no log has been delivered yet.
+	fromBlock *big.Int
+	// lastBlock and lastIndex identify the last delivered log, if delivered is true.
+	lastBlock uint64
+	lastIndex uint
+	delivered bool
+	// blockHashes are the hashes of the recent blocks logs were delivered of, by block number,
+	// so that logs of a block replacing one of them in a reorg are not mistaken for delivered logs.
+	blockHashes map[uint64]common.Hash
+
+	errCh     chan error
+	quit      chan struct{}
+	done      chan struct{}
+	unsubOnce sync.Once
+}
+
+// SubscribeLogs subscribes to logs matching the query, delivering them to out.
+// An error is only returned if the initial subscription fails; later failures are retried
+// until the subscription is unsubscribed.
+func SubscribeLogs(ctx context.Context, lgr log.Logger, client ethereum.LogFilterer, query ethereum.FilterQuery, out chan<- types.Log) (*LogSubscription, error) {
+	s := &LogSubscription{
+		client:      client,
+		query:       query,
+		out:         out,
+		log:         lgr,
+		backoff:     backoff.Exponential(),
+		fromBlock:   query.FromBlock,
+		blockHashes: make(map[uint64]common.Hash),
+		errCh:       make(chan error, 1),
+		quit:        make(chan struct{}),
+		done:        make(chan struct{}),
+	}
+	// Remember the head at subscription time, so logs missed before the first delivered log can be backfilled too.
+	if bn, ok := client.(blockNumberer); ok {
+		if head, err := bn.BlockNumber(ctx); err == nil {
+			s.fromBlock = new(big.Int).SetUint64(head)
+		}
+	}
+	raw := make(chan types.Log)
+	sub, err := client.SubscribeFilterLogs(ctx, query, raw)
+	if err != nil {
+		return nil, err
+	}
+	go s.loop(sub, raw)
+	return s, nil
+}
+
+// Unsubscribe stops delivering logs and closes the error channel.
+func (s *LogSubscription) Unsubscribe() {
+	s.unsubOnce.Do(func() {
+		close(s.quit)
+		<-s.done
+		close(s.errCh)
+	})
+}
+
+// Err returns the subscription error channel. Failures of the underlying subscription are retried
+// rather than reported, so the channel is only ever closed when Unsubscribe is called.
+func (s *LogSubscription) Err() <-chan error {
+	return s.errCh
+}
+
+func (s *LogSubscription) loop(sub ethereum.Subscription, raw chan types.Log) {
+	defer close(s.done)
+	for {
+		var subErr <-chan error
+		if sub != nil {
+			subErr = sub.Err()
+		}
+		select {
+		case l := <-raw:
+			if !s.deliver(l) {
+				if sub != nil {
+					sub.Unsubscribe()
+				}
+				return
+			}
+		case err := <-subErr:
+			s.log.Warn("Log subscription dropped, resubscribing", "err", err)
+			sub, raw = s.resubscribe()
+			if sub == nil {
+				return
+			}
+		case <-s.quit:
+			if sub != nil {
+				sub.Unsubscribe()
+			}
+			return
+		}
+	}
+}
+
+// resubscribe retries subscribing until it succeeds and the missed logs are backfilled.
+// It returns a nil subscription if the subscription was unsubscribed in the meantime.
+func (s *LogSubscription) resubscribe() (ethereum.Subscription, chan types.Log) {
+	for attempt := 0; ; attempt++ {
+		select {
+		case <-time.After(s.backoff.Duration(attempt)):
+		case <-s.quit:
+			return nil, nil
+		}
+		raw := make(chan types.Log)
+		sub, err := s.client.SubscribeFilterLogs(context.Background(), s.query, raw)
+		if err != nil {
+			s.log.Warn("Failed to resubscribe to logs", "attempt", attempt, "err", err)
+			continue
+		}
+		quit, err := s.backfill()
+		if quit {
+			sub.Unsubscribe()
+			return nil, nil
+		}
+		if err != nil {
+			s.log.Warn("Failed to backfill missed logs", "attempt", attempt, "err", err)
+			sub.Unsubscribe()
+			continue
+		}
+		s.log.Info("Resubscribed to logs", "attempts", attempt+1)
+		return sub, raw
+	}
+}
+
+// backfill delivers the logs emitted since the last delivered log, or since subscribing if none was delivered.
+// It returns true if the subscription was unsubscribed while delivering.
+func (s *LogSubscription) backfill() (bool, error) {
+	q := s.query
+	if s.delivered {
+		q.FromBlock = new(big.Int).SetUint64(s.lastBlock)
+	} else if s.fromBlock != nil {
+		q.FromBlock = s.fromBlock
+	} else {
+		s.log.Warn("Unable to backfill logs, no starting block known")
+		return false, nil
+	}
+	q.ToBlock = nil
+	logs, err := s.client.FilterLogs(context.Background(), q)
+	if err != nil {
+		return false, err
+	}
+	for _, l := range logs {
+		if !s.deliver(l) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// deliver sends the log to the output channel unless it was delivered before.
+// Removed logs, signalling a reorg, are always delivered, and rewind the last delivered position
+// so that the logs replacing them on the new chain are delivered too.
+// A log of a recent block with a different hash than the block logs were delivered of before is
+// from a reorged chain, even if the removed logs were missed, so it is delivered and rewinds too.
+// It returns false if the subscription was unsubscribed while waiting to deliver.
+func (s *LogSubscription) deliver(l types.Log) bool {
+	seen := s.seen(l)
+	if l.Removed {
+		if seen {
+			s.rewind(l)
+		}
+	} else if seen {
+		return true
+	} else {
+		s.advance(l)
+	}
+	select {
+	case s.out <- l:
+		return true
+	case <-s.quit:
+		return false
+	}
+}
+
+// seen returns whether the log was delivered before. Logs of recent blocks are only considered
+// delivered if their block hash matches the block logs were delivered of.
+func (s *LogSubscription) seen(l types.Log) bool {
+	if !s.delivered || l.BlockNumber > s.lastBlock || (l.BlockNumber == s.lastBlock && l.Index > s.lastIndex) {
+		return false
+	}
+	if s.lastBlock-l.BlockNumber >= trackedBlocks {
+		// too old to tell, assume it is from the same chain
+		return true
+	}
+	hash, ok := s.blockHashes[l.BlockNumber]
+	return ok && hash == l.BlockHash
+}
+
+// advance makes the log the last delivered position. Since it was not seen, a log at or before the
+// last delivered position is from a new chain, and the blocks after it are forgotten.
+func (s *LogSubscription) advance(l types.Log) {
+	s.forgetFrom(l.BlockNumber + 1)
+	if hash, ok := s.blockHashes[l.BlockNumber]; ok && hash != l.BlockHash {
+		s.log.Warn("Block of delivered logs was reorged", "block", l.BlockNumber, "old_hash", hash, "new_hash", l.BlockHash)
+	}
+	s.lastBlock, s.lastIndex, s.delivered = l.BlockNumber, l.Index, true
+	s.blockHashes[l.BlockNumber] = l.BlockHash
+	if l.BlockNumber >= trackedBlocks {
+		for n := range s.blockHashes {
+			if n <= l.BlockNumber-trackedBlocks {
+				delete(s.blockHashes, n)
+			}
+		}
+	}
+}
+
+// rewind moves the last delivered position back to just before the given removed log,
+// and forgets the hash of its block, since that block is no longer part of the chain.
+func (s *LogSubscription) rewind(l types.Log) {
+	s.forgetFrom(l.BlockNumber)
+	switch {
+	case l.Index > 0:
+		s.lastBlock, s.lastIndex = l.BlockNumber, l.Index-1
+	case l.BlockNumber > 0:
+		s.lastBlock, s.lastIndex = l.BlockNumber-1, ^uint(0)
+	default:
+		s.delivered = false
+	}
+}
+
+// forgetFrom forgets the hashes of the blocks from the given number on.
+func (s *LogSubscription) forgetFrom(number uint64) {
+	for n := range s.blockHashes {
+		if n >= number {
+			delete(s.blockHashes, n)
+		}
+	}
+}
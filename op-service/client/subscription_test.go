@@ -0,0 +1,201 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/backoff"
+)
+
+type stubSubscription struct {
+	errCh chan error
+	once  sync.Once
+}
+
+func (s *stubSubscription) Unsubscribe() {
+	s.once.Do(func() { close(s.errCh) })
+}
+
+func (s *stubSubscription) Err() <-chan error {
+	return s.errCh
+}
+
+type stubLogClient struct {
+	mtx       sync.Mutex
+	head      uint64
+	subs      []*stubSubscription
+	chans     []chan<- types.Log
+	backfill  []types.Log
+	filterErr error
+	queries   []ethereum.FilterQuery
+}
+
+func (c *stubLogClient) BlockNumber(ctx context.Context) (uint64, error) {
+	return c.head, nil
+}
+
+func (c *stubLogClient) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	c.queries = append(c.queries, q)
+	return c.backfill, c.filterErr
+}
+
+func (c *stubLogClient) SubscribeFilterLogs(ctx context.Context, q ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	sub := &stubSubscription{errCh: make(chan error, 1)}
+	c.subs = append(c.subs, sub)
+	c.chans = append(c.chans, ch)
+	return sub, nil
+}
+
+func (c *stubLogClient) latest() (*stubSubscription, chan<- types.Log, int) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.subs[len(c.subs)-1], c.chans[len(c.chans)-1], len(c.subs)
+}
+
+func newTestLogSubscription(t *testing.T, client *stubLogClient) (*LogSubscription, chan types.Log) {
+	out := make(chan types.Log, 10)
+	sub, err := SubscribeLogs(context.Background(), testlog.Logger(t, log.LvlError), client, ethereum.FilterQuery{}, out)
+	require.NoError(t, err)
+	sub.backoff = backoff.Fixed(0)
+	t.Cleanup(sub.Unsubscribe)
+	return sub, out
+}
+
+func receiveLog(t *testing.T, out <-chan types.Log) types.Log {
+	select {
+	case l := <-out:
+		return l
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for log")
+		return types.Log{}
+	}
+}
+
+func TestLogSubscription_DeliversLogs(t *testing.T) {
+	client := &stubLogClient{head: 10}
+	_, out := newTestLogSubscription(t, client)
+	_, ch, _ := client.latest()
+
+	ch <- types.Log{BlockNumber: 11, Index: 0}
+	require.Equal(t, uint64(11), receiveLog(t, out).BlockNumber)
+}
+
+func TestLogSubscription_ResubscribesAndBackfills(t *testing.T) {
+	client := &stubLogClient{head: 10}
+	_, out := newTestLogSubscription(t, client)
+	sub, ch, _ := client.latest()
+
+	ch <- types.Log{BlockNumber: 11, Index: 0}
+	receiveLog(t, out)
+
+	client.mtx.Lock()
+	client.backfill = []types.Log{
+		{BlockNumber: 11, Index: 0}, // already delivered
+		{BlockNumber: 11, Index: 1},
+		{BlockNumber: 12, Index: 0},
+	}
+	client.mtx.Unlock()
+	sub.errCh <- errors.New("connection dropped")
+
+	require.Equal(t, types.Log{BlockNumber: 11, Index: 1}, receiveLog(t, out))
+	require.Equal(t, types.Log{BlockNumber: 12, Index: 0}, receiveLog(t, out))
+
+	_, ch, count := client.latest()
+	require.Equal(t, 2, count)
+	client.mtx.Lock()
+	require.Equal(t, big.NewInt(11), client.queries[0].FromBlock)
+	client.mtx.Unlock()
+
+	// Logs also delivered by the backfill are skipped on the new subscription.
+	ch <- types.Log{BlockNumber: 12, Index: 0}
+	ch <- types.Log{BlockNumber: 13, Index: 0}
+	require.Equal(t, uint64(13), receiveLog(t, out).BlockNumber)
+}
+
+func TestLogSubscription_BackfillsFromHeadWithoutDeliveredLogs(t *testing.T) {
+	client := &stubLogClient{head: 10}
+	_, out := newTestLogSubscription(t, client)
+	sub, _, _ := client.latest()
+
+	client.mtx.Lock()
+	client.backfill = []types.Log{{BlockNumber: 10, Index: 3}}
+	client.mtx.Unlock()
+	sub.errCh <- errors.New("connection dropped")
+
+	require.Equal(t, uint64(10), receiveLog(t, out).BlockNumber)
+	client.mtx.Lock()
+	require.Equal(t, big.NewInt(10), client.queries[0].FromBlock)
+	client.mtx.Unlock()
+}
+
+func TestLogSubscription_DeliversReplacedLogsAfterReorg(t *testing.T) {
+	client := &stubLogClient{head: 10}
+	_, out := newTestLogSubscription(t, client)
+	_, ch, _ := client.latest()
+
+	ch <- types.Log{BlockNumber: 11, Index: 0}
+	receiveLog(t, out)
+	ch <- types.Log{BlockNumber: 11, Index: 0, Removed: true}
+	require.True(t, receiveLog(t, out).Removed)
+	ch <- types.Log{BlockNumber: 11, Index: 0, TxIndex: 1}
+	require.Equal(t, uint(1), receiveLog(t, out).TxIndex)
+}
+
+func TestLogSubscription_DeliversLogsOfReorgedBlock(t *testing.T) {
+	client := &stubLogClient{head: 10}
+	_, out := newTestLogSubscription(t, client)
+	sub, ch, _ := client.latest()
+
+	oldHash, newHash := common.Hash{0xaa}, common.Hash{0xbb}
+	ch <- types.Log{BlockNumber: 11, Index: 0, BlockHash: oldHash}
+	ch <- types.Log{BlockNumber: 11, Index: 1, BlockHash: oldHash}
+	receiveLog(t, out)
+	receiveLog(t, out)
+
+	// Block 11 is reorged while the connection is down, so the removed logs are never received.
+	client.mtx.Lock()
+	client.backfill = []types.Log{
+		{BlockNumber: 11, Index: 0, BlockHash: newHash},
+		{BlockNumber: 12, Index: 0, BlockHash: common.Hash{0xcc}},
+	}
+	client.mtx.Unlock()
+	sub.errCh <- errors.New("connection dropped")
+
+	require.Equal(t, types.Log{BlockNumber: 11, Index: 0, BlockHash: newHash}, receiveLog(t, out))
+	require.Equal(t, uint64(12), receiveLog(t, out).BlockNumber)
+
+	// The new subscription only delivers logs that were not delivered yet.
+	_, ch, count := client.latest()
+	require.Equal(t, 2, count)
+	ch <- types.Log{BlockNumber: 11, Index: 0, BlockHash: newHash}
+	ch <- types.Log{BlockNumber: 12, Index: 0, BlockHash: common.Hash{0xcc}}
+	ch <- types.Log{BlockNumber: 13, Index: 0, BlockHash: common.Hash{0xdd}}
+	require.Equal(t, uint64(13), receiveLog(t, out).BlockNumber)
+}
+
+func TestLogSubscription_UnsubscribeClosesErr(t *testing.T) {
+	client := &stubLogClient{head: 10}
+	sub, _ := newTestLogSubscription(t, client)
+	inner, _, _ := client.latest()
+
+	sub.Unsubscribe()
+	_, ok := <-sub.Err()
+	require.False(t, ok)
+	_, ok = <-inner.Err()
+	require.False(t, ok, "underlying subscription should be unsubscribed")
+}